@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"delivery/cmd"
 	httpin "delivery/internal/adapters/in/http"
+	"delivery/internal/adapters/in/http/problems"
 	"delivery/internal/adapters/out/postgres/courierrepo"
 	"delivery/internal/adapters/out/postgres/orderrepo"
 	"delivery/internal/generated/servers"
 	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/inbox"
 	"delivery/internal/pkg/outbox"
 	"fmt"
 	"github.com/joho/godotenv"
@@ -22,6 +24,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	_ "time/tzdata"
 )
 
 func main() {
@@ -60,18 +63,32 @@ func main() {
 
 func getConfigs() cmd.Config {
 	config := cmd.Config{
-		HttpPort:                  goDotEnvVariable("HTTP_PORT"),
-		DbHost:                    goDotEnvVariable("DB_HOST"),
-		DbPort:                    goDotEnvVariable("DB_PORT"),
-		DbUser:                    goDotEnvVariable("DB_USER"),
-		DbPassword:                goDotEnvVariable("DB_PASSWORD"),
-		DbName:                    goDotEnvVariable("DB_NAME"),
-		DbSslMode:                 goDotEnvVariable("DB_SSLMODE"),
-		GeoServiceGrpcHost:        goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
-		KafkaHost:                 goDotEnvVariable("KAFKA_HOST"),
-		KafkaConsumerGroup:        goDotEnvVariable("KAFKA_CONSUMER_GROUP"),
-		KafkaBasketConfirmedTopic: goDotEnvVariable("KAFKA_BASKET_CONFIRMED_TOPIC"),
-		KafkaOrderChangedTopic:    goDotEnvVariable("KAFKA_ORDER_CHANGED_TOPIC"),
+		HttpPort:                     goDotEnvVariable("HTTP_PORT"),
+		DbHost:                       goDotEnvVariable("DB_HOST"),
+		DbPort:                       goDotEnvVariable("DB_PORT"),
+		DbUser:                       goDotEnvVariable("DB_USER"),
+		DbPassword:                   goDotEnvVariable("DB_PASSWORD"),
+		DbName:                       goDotEnvVariable("DB_NAME"),
+		DbSslMode:                    goDotEnvVariable("DB_SSLMODE"),
+		GeoServiceGrpcHost:           goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
+		KafkaHost:                    goDotEnvVariable("KAFKA_HOST"),
+		KafkaConsumerGroup:           goDotEnvVariable("KAFKA_CONSUMER_GROUP"),
+		KafkaBasketConfirmedTopic:    goDotEnvVariable("KAFKA_BASKET_CONFIRMED_TOPIC"),
+		KafkaOrderChangedTopic:       goDotEnvVariable("KAFKA_ORDER_CHANGED_TOPIC"),
+		KafkaOrderChangedContentType: goDotEnvVariable("KAFKA_ORDER_CHANGED_CONTENT_TYPE"),
+		KafkaBasketConfirmedDlqTopic: goDotEnvVariable("KAFKA_BASKET_CONFIRMED_DLQ_TOPIC"),
+		KafkaConsumerMaxAttempts:     goDotEnvVariable("KAFKA_CONSUMER_MAX_ATTEMPTS"),
+		KafkaConsumerRetryBackoff:    goDotEnvVariable("KAFKA_CONSUMER_RETRY_BACKOFF"),
+		DeliveryTimezone:             goDotEnvVariable("DELIVERY_TIMEZONE"),
+		DispatchStrategy:             goDotEnvVariable("DISPATCH_STRATEGY"),
+		AssignOrdersMode:             goDotEnvVariable("ASSIGN_ORDERS_MODE"),
+		MapZone:                      goDotEnvVariable("MAP_ZONE"),
+		MapMinX:                      goDotEnvVariable("MAP_MIN_X"),
+		MapMinY:                      goDotEnvVariable("MAP_MIN_Y"),
+		MapMaxX:                      goDotEnvVariable("MAP_MAX_X"),
+		MapMaxY:                      goDotEnvVariable("MAP_MAX_Y"),
+		MovementModel:                goDotEnvVariable("MOVEMENT_MODEL"),
+		MapBlockedCells:              goDotEnvVariable("MAP_BLOCKED_CELLS"),
 	}
 	return config
 }
@@ -165,29 +182,70 @@ func mustAutoMigrate(db *gorm.DB) {
 		log.Fatalf("Ошибка миграции: %v", err)
 	}
 
+	err = db.AutoMigrate(&courierrepo.StoredOrderDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
 	err = db.AutoMigrate(&orderrepo.OrderDTO{})
 	if err != nil {
 		log.Fatalf("Ошибка миграции: %v", err)
 	}
 
+	err = db.AutoMigrate(&orderrepo.AddressDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&orderrepo.ItemDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&orderrepo.StatusHistoryDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
 	err = db.AutoMigrate(&outbox.Message{})
 	if err != nil {
 		log.Fatalf("Ошибка миграции: %v", err)
 	}
+
+	err = db.AutoMigrate(&inbox.Message{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = courierrepo.MigrateLegacyStoredOrders(db)
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
 }
 
 func startWebServer(compositionRoot *cmd.CompositionRoot, port string) {
 	handlers, err := httpin.NewServer(
 		compositionRoot.NewCreateOrderCommandHandler(),
 		compositionRoot.NewCreateCourierCommandHandler(),
+		compositionRoot.NewCancelOrderCommandHandler(),
+		compositionRoot.NewChangeCourierShiftCommandHandler(),
+		compositionRoot.NewUpdateCourierCommandHandler(),
+		compositionRoot.NewDeactivateCourierCommandHandler(),
+		compositionRoot.NewAddStoragePlaceCommandHandler(),
+		compositionRoot.NewRemoveStoragePlaceCommandHandler(),
 		compositionRoot.NewGetAllCouriersQueryHandler(),
 		compositionRoot.NewGetNotCompletedOrdersQueryHandler(),
+		compositionRoot.NewGetOrderQueryHandler(),
+		compositionRoot.NewGetCourierQueryHandler(),
+		compositionRoot.NewDeadLetterReplayer(),
+		compositionRoot.NewGrid(),
 	)
 	if err != nil {
 		log.Fatalf("Ошибка инициализации HTTP Server: %v", err)
 	}
 
 	e := echo.New()
+	e.HTTPErrorHandler = problems.NewHTTPErrorHandler(e)
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
 		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
@@ -261,6 +319,10 @@ func startCron(compositionRoot *cmd.CompositionRoot) {
 	if err != nil {
 		log.Fatalf("ошибка при добавлении задачи: %v", err)
 	}
+	_, err = c.AddJob("@every 5s", compositionRoot.NewOutboxJob())
+	if err != nil {
+		log.Fatalf("ошибка при добавлении задачи: %v", err)
+	}
 	c.Start()
 }
 
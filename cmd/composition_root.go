@@ -9,17 +9,22 @@ import (
 	"delivery/internal/core/application/eventhandlers"
 	"delivery/internal/core/application/usecases/commands"
 	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/domain/model/order"
 	"delivery/internal/core/domain/sevices"
 	"delivery/internal/core/ports"
 	"delivery/internal/jobs"
 	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/outbox"
+	"fmt"
 	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 	"log"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type CompositionRoot struct {
@@ -27,8 +32,11 @@ type CompositionRoot struct {
 	gormDb    *gorm.DB
 	geoClient ports.GeoClient
 
-	closers []Closer
-	onceGeo sync.Once
+	orderProducer ports.OrderProducer
+
+	closers           []Closer
+	onceGeo           sync.Once
+	onceOrderProducer sync.Once
 }
 
 func NewCompositionRoot(configs Config, gormDb *gorm.DB) *CompositionRoot {
@@ -38,37 +46,191 @@ func NewCompositionRoot(configs Config, gormDb *gorm.DB) *CompositionRoot {
 	}
 }
 
+// NewGrid собирает карту города из конфигурации. Незаданные зона и границы берутся из исторической карты 10x10
+func (cr *CompositionRoot) NewGrid() kernel.Grid {
+	defaultGrid := kernel.DefaultGrid()
+	bounds := []struct {
+		name  string
+		value string
+		def   int
+	}{
+		{"MapMinX", cr.configs.MapMinX, defaultGrid.MinX()},
+		{"MapMinY", cr.configs.MapMinY, defaultGrid.MinY()},
+		{"MapMaxX", cr.configs.MapMaxX, defaultGrid.MaxX()},
+		{"MapMaxY", cr.configs.MapMaxY, defaultGrid.MaxY()},
+	}
+	values := make([]int, len(bounds))
+	for i, bound := range bounds {
+		values[i] = bound.def
+		if bound.value == "" {
+			continue
+		}
+		value, err := strconv.Atoi(bound.value)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", bound.name, err)
+		}
+		values[i] = value
+	}
+
+	zone := cr.configs.MapZone
+	if zone == "" {
+		zone = defaultGrid.Zone()
+	}
+	grid, err := kernel.NewGrid(zone, values[0], values[1], values[2], values[3])
+	if err != nil {
+		log.Fatalf("cannot create Grid: %v", err)
+	}
+
+	blockedCells, err := parseCells(cr.configs.MapBlockedCells)
+	if err != nil {
+		log.Fatalf("invalid MapBlockedCells: %v", err)
+	}
+	grid, err = grid.WithBlockedCells(blockedCells...)
+	if err != nil {
+		log.Fatalf("cannot block Grid cells: %v", err)
+	}
+	return grid
+}
+
+// NewZones - карты, на которых восстанавливаются сохраненные точки. Сервис работает с одной картой города
+func (cr *CompositionRoot) NewZones() kernel.Zones {
+	zones, err := kernel.NewZones(cr.NewGrid())
+	if err != nil {
+		log.Fatalf("cannot create Zones: %v", err)
+	}
+	return zones
+}
+
+// parseCells разбирает список клеток вида "3:4,3:5"
+func parseCells(value string) ([]kernel.Cell, error) {
+	var cells []kernel.Cell
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		coordinates := strings.Split(item, ":")
+		if len(coordinates) != 2 {
+			return nil, fmt.Errorf("cell %q must look like x:y", item)
+		}
+		x, err := strconv.Atoi(strings.TrimSpace(coordinates[0]))
+		if err != nil {
+			return nil, fmt.Errorf("cell %q: %w", item, err)
+		}
+		y, err := strconv.Atoi(strings.TrimSpace(coordinates[1]))
+		if err != nil {
+			return nil, fmt.Errorf("cell %q: %w", item, err)
+		}
+		cells = append(cells, kernel.Cell{X: x, Y: y})
+	}
+	return cells, nil
+}
+
 func (cr *CompositionRoot) NewDispatchService() services.DispatchService {
-	return services.NewDispatchService()
+	navigator := cr.NewNavigator()
+	strategy, err := services.NewDispatchStrategy(cr.configs.DispatchStrategy, navigator)
+	if err != nil {
+		log.Fatalf("cannot create DispatchStrategy: %v", err)
+	}
+	dispatchService, err := services.NewDispatchServiceWithStrategy(strategy, navigator)
+	if err != nil {
+		log.Fatalf("cannot create DispatchService: %v", err)
+	}
+	return dispatchService
 }
 
-func (cr *CompositionRoot) NewUnitOfWork() ports.UnitOfWork {
-	unitOfWork, err := postgres.NewUnitOfWork(cr.gormDb)
+func (cr *CompositionRoot) NewNavigator() services.Navigator {
+	navigator, err := services.NewNavigator(cr.configs.MovementModel)
 	if err != nil {
-		log.Fatalf("cannot create UnitOfWork: %v", err)
+		log.Fatalf("cannot create Navigator: %v", err)
+	}
+	return navigator
+}
+
+// NewUnitOfWorkFactory - UnitOfWork на каждый вызов обработчика. UnitOfWork хранит открытую транзакцию,
+// поэтому обработчики, которые вызываются из HTTP-запросов, консьюмера и джобов одновременно, его не делят
+func (cr *CompositionRoot) NewUnitOfWorkFactory() ports.UnitOfWorkFactory {
+	zones := cr.NewZones()
+	return func() (ports.UnitOfWork, error) {
+		return postgres.NewUnitOfWork(cr.gormDb, zones)
 	}
-	return unitOfWork
 }
 
 func (cr *CompositionRoot) NewCreateOrderCommandHandler() commands.CreateOrderCommandHandler {
-	createOrderCommandHandler, err := commands.NewCreateOrderCommandHandler(cr.NewUnitOfWork(), cr.NewGeoClient())
+	createOrderCommandHandler, err := commands.NewCreateOrderCommandHandler(cr.NewUnitOfWorkFactory(), cr.NewGeoClient())
 	if err != nil {
 		log.Fatalf("cannot create CreateOrderCommandHandler: %v", err)
 	}
 	return createOrderCommandHandler
 }
 
+func (cr *CompositionRoot) NewRandomSource() kernel.RandomSource {
+	return kernel.NewRandomSource(time.Now().UnixNano())
+}
+
 func (cr *CompositionRoot) NewCreateCourierCommandHandler() commands.CreateCourierCommandHandler {
-	createCourierCommandHandler, err := commands.NewCreateCourierCommandHandler(cr.NewUnitOfWork())
+	createCourierCommandHandler, err := commands.NewCreateCourierCommandHandler(
+		cr.NewUnitOfWorkFactory(), cr.NewRandomSource(), cr.NewGrid())
 	if err != nil {
 		log.Fatalf("cannot create CreateCourierCommandHandler: %v", err)
 	}
 	return createCourierCommandHandler
 }
 
+func (cr *CompositionRoot) NewCancelOrderCommandHandler() commands.CancelOrderCommandHandler {
+	cancelOrderCommandHandler, err := commands.NewCancelOrderCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create CancelOrderCommandHandler: %v", err)
+	}
+	return cancelOrderCommandHandler
+}
+
+func (cr *CompositionRoot) NewChangeCourierShiftCommandHandler() commands.ChangeCourierShiftCommandHandler {
+	changeCourierShiftCommandHandler, err := commands.NewChangeCourierShiftCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create ChangeCourierShiftCommandHandler: %v", err)
+	}
+	return changeCourierShiftCommandHandler
+}
+
+func (cr *CompositionRoot) NewUpdateCourierCommandHandler() commands.UpdateCourierCommandHandler {
+	updateCourierCommandHandler, err := commands.NewUpdateCourierCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create UpdateCourierCommandHandler: %v", err)
+	}
+	return updateCourierCommandHandler
+}
+
+func (cr *CompositionRoot) NewDeactivateCourierCommandHandler() commands.DeactivateCourierCommandHandler {
+	deactivateCourierCommandHandler, err := commands.NewDeactivateCourierCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create DeactivateCourierCommandHandler: %v", err)
+	}
+	return deactivateCourierCommandHandler
+}
+
+func (cr *CompositionRoot) NewAddStoragePlaceCommandHandler() commands.AddStoragePlaceCommandHandler {
+	addStoragePlaceCommandHandler, err := commands.NewAddStoragePlaceCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create AddStoragePlaceCommandHandler: %v", err)
+	}
+	return addStoragePlaceCommandHandler
+}
+
+func (cr *CompositionRoot) NewRemoveStoragePlaceCommandHandler() commands.RemoveStoragePlaceCommandHandler {
+	removeStoragePlaceCommandHandler, err := commands.NewRemoveStoragePlaceCommandHandler(cr.NewUnitOfWorkFactory())
+	if err != nil {
+		log.Fatalf("cannot create RemoveStoragePlaceCommandHandler: %v", err)
+	}
+	return removeStoragePlaceCommandHandler
+}
+
 func (cr *CompositionRoot) NewAssignOrdersCommandHandler() commands.AssignOrdersCommandHandler {
-	assignOrdersCommandHandler, err := commands.NewAssignOrdersCommandHandler(
-		cr.NewUnitOfWork(), cr.NewDispatchService())
+	newHandler := commands.NewAssignOrdersCommandHandler
+	if cr.configs.AssignOrdersMode == assignOrdersBatchMode {
+		newHandler = commands.NewAssignOrdersBatchCommandHandler
+	}
+	assignOrdersCommandHandler, err := newHandler(cr.NewUnitOfWorkFactory(), cr.NewDispatchService())
 	if err != nil {
 		log.Fatalf("cannot create AssignOrdersCommandHandler: %v", err)
 	}
@@ -77,7 +239,7 @@ func (cr *CompositionRoot) NewAssignOrdersCommandHandler() commands.AssignOrders
 
 func (cr *CompositionRoot) NewMoveCouriersCommandHandler() commands.MoveCouriersCommandHandler {
 	moveCouriersCommandHandler, err := commands.NewMoveCouriersCommandHandler(
-		cr.NewUnitOfWork())
+		cr.NewUnitOfWorkFactory(), cr.NewNavigator())
 	if err != nil {
 		log.Fatalf("cannot create MoveCouriersCommandHandler: %v", err)
 	}
@@ -100,6 +262,22 @@ func (cr *CompositionRoot) NewGetNotCompletedOrdersQueryHandler() queries.GetNot
 	return getNotCompletedOrdersQueryHandler
 }
 
+func (cr *CompositionRoot) NewGetOrderQueryHandler() queries.GetOrderQueryHandler {
+	getOrderQueryHandler, err := queries.NewGetOrderQueryHandler(cr.gormDb)
+	if err != nil {
+		log.Fatalf("cannot create GetOrderQueryHandler: %v", err)
+	}
+	return getOrderQueryHandler
+}
+
+func (cr *CompositionRoot) NewGetCourierQueryHandler() queries.GetCourierQueryHandler {
+	getCourierQueryHandler, err := queries.NewGetCourierQueryHandler(cr.gormDb, cr.NewZones(), cr.NewNavigator())
+	if err != nil {
+		log.Fatalf("cannot create GetCourierQueryHandler: %v", err)
+	}
+	return getCourierQueryHandler
+}
+
 func (cr *CompositionRoot) NewAssignOrdersJob() cron.Job {
 	job, err := jobs.NewAssignOrdersJob(cr.NewAssignOrdersCommandHandler())
 	if err != nil {
@@ -118,7 +296,7 @@ func (cr *CompositionRoot) NewMoveCouriersJob() cron.Job {
 
 func (cr *CompositionRoot) NewGeoClient() ports.GeoClient {
 	cr.onceGeo.Do(func() {
-		client, err := grpcout.NewClient(cr.configs.GeoServiceGrpcHost)
+		client, err := grpcout.NewClient(cr.configs.GeoServiceGrpcHost, cr.NewGrid())
 		if err != nil {
 			log.Fatalf("cannot create GeoClient: %v", err)
 		}
@@ -134,6 +312,9 @@ func (cr *CompositionRoot) NewBasketConfirmedConsumer() kafkain.BasketConfirmedC
 		cr.configs.KafkaConsumerGroup,
 		cr.configs.KafkaBasketConfirmedTopic,
 		cr.NewCreateOrderCommandHandler(),
+		cr.NewDeadLetterQueue(),
+		cr.NewRetryPolicy(),
+		cr.NewDeliveryTimezone(),
 	)
 	if err != nil {
 		log.Fatalf("cannot create BasketConfirmedConsumer: %v", err)
@@ -142,6 +323,90 @@ func (cr *CompositionRoot) NewBasketConfirmedConsumer() kafkain.BasketConfirmedC
 	return consumer
 }
 
+// NewDeliveryTimezone - часовой пояс, в котором корзина задает часы периода доставки
+func (cr *CompositionRoot) NewDeliveryTimezone() *time.Location {
+	if cr.configs.DeliveryTimezone == "" {
+		return time.UTC
+	}
+	timezone, err := time.LoadLocation(cr.configs.DeliveryTimezone)
+	if err != nil {
+		log.Fatalf("invalid DeliveryTimezone: %v", err)
+	}
+	return timezone
+}
+
+func (cr *CompositionRoot) NewDeadLetterQueue() kafkain.DeadLetterQueue {
+	deadLetterQueue, err := kafkain.NewDeadLetterQueue([]string{cr.configs.KafkaHost}, cr.deadLetterTopic())
+	if err != nil {
+		log.Fatalf("cannot create DeadLetterQueue: %v", err)
+	}
+	return deadLetterQueue
+}
+
+func (cr *CompositionRoot) NewDeadLetterReplayer() ports.DeadLetterReplayer {
+	replayer, err := kafkain.NewDeadLetterReplayer(
+		[]string{cr.configs.KafkaHost},
+		cr.configs.KafkaConsumerGroup+"-dlq-replay",
+		cr.deadLetterTopic(),
+		cr.configs.KafkaBasketConfirmedTopic,
+	)
+	if err != nil {
+		log.Fatalf("cannot create DeadLetterReplayer: %v", err)
+	}
+	return replayer
+}
+
+// NewRetryPolicy собирает политику повторов из конфигурации. Незаданные значения берутся из политики по умолчанию
+func (cr *CompositionRoot) NewRetryPolicy() kafkain.RetryPolicy {
+	policy := kafkain.DefaultRetryPolicy()
+	maxAttempts, initialBackoff := policy.MaxAttempts, policy.InitialBackoff
+	if cr.configs.KafkaConsumerMaxAttempts != "" {
+		value, err := strconv.Atoi(cr.configs.KafkaConsumerMaxAttempts)
+		if err != nil {
+			log.Fatalf("invalid KafkaConsumerMaxAttempts: %v", err)
+		}
+		maxAttempts = value
+	}
+	if cr.configs.KafkaConsumerRetryBackoff != "" {
+		value, err := time.ParseDuration(cr.configs.KafkaConsumerRetryBackoff)
+		if err != nil {
+			log.Fatalf("invalid KafkaConsumerRetryBackoff: %v", err)
+		}
+		initialBackoff = value
+	}
+
+	policy, err := kafkain.NewRetryPolicy(maxAttempts, initialBackoff, max(policy.MaxBackoff, initialBackoff))
+	if err != nil {
+		log.Fatalf("cannot create RetryPolicy: %v", err)
+	}
+	return policy
+}
+
+func (cr *CompositionRoot) deadLetterTopic() string {
+	if cr.configs.KafkaBasketConfirmedDlqTopic != "" {
+		return cr.configs.KafkaBasketConfirmedDlqTopic
+	}
+	return cr.configs.KafkaBasketConfirmedTopic + ".dlq"
+}
+
+func (cr *CompositionRoot) NewOrderCreatedDomainEventHandler() ddd.EventHandler {
+	producer := cr.NewOrderProducer()
+	handler, err := eventhandlers.NewOrderCreatedDomainEventHandler(producer)
+	if err != nil {
+		log.Fatalf("cannot create OrderCreatedDomainEventHandler: %v", err)
+	}
+	return handler
+}
+
+func (cr *CompositionRoot) NewOrderAssignedDomainEventHandler() ddd.EventHandler {
+	producer := cr.NewOrderProducer()
+	handler, err := eventhandlers.NewOrderAssignedDomainEventHandler(producer)
+	if err != nil {
+		log.Fatalf("cannot create OrderAssignedDomainEventHandler: %v", err)
+	}
+	return handler
+}
+
 func (cr *CompositionRoot) NewOrderCompletedDomainEventHandler() ddd.EventHandler {
 	producer := cr.NewOrderProducer()
 	handler, err := eventhandlers.NewOrderCompletedDomainEventHandler(producer)
@@ -151,19 +416,35 @@ func (cr *CompositionRoot) NewOrderCompletedDomainEventHandler() ddd.EventHandle
 	return handler
 }
 
+func (cr *CompositionRoot) NewOrderCancelledDomainEventHandler() ddd.EventHandler {
+	producer := cr.NewOrderProducer()
+	handler, err := eventhandlers.NewOrderCancelledDomainEventHandler(producer)
+	if err != nil {
+		log.Fatalf("cannot create OrderCancelledDomainEventHandler: %v", err)
+	}
+	return handler
+}
+
 func (cr *CompositionRoot) NewMediatrWithSubscriptions() ddd.Mediatr {
 	mediatr := ddd.NewMediatr()
+	mediatr.Subscribe(cr.NewOrderCreatedDomainEventHandler(), order.NewEmptyCreatedDomainEvent())
+	mediatr.Subscribe(cr.NewOrderAssignedDomainEventHandler(), order.NewEmptyAssignedDomainEvent())
 	mediatr.Subscribe(cr.NewOrderCompletedDomainEventHandler(), order.NewEmptyCompletedDomainEvent())
+	mediatr.Subscribe(cr.NewOrderCancelledDomainEventHandler(), order.NewEmptyCancelledDomainEvent())
 	return mediatr
 }
 
 func (cr *CompositionRoot) NewOrderProducer() ports.OrderProducer {
-	producer, err := kafkaout.NewOrderProducer([]string{cr.configs.KafkaHost}, cr.configs.KafkaOrderChangedTopic)
-	if err != nil {
-		log.Fatalf("cannot create OrderProducer: %v", err)
-	}
-	cr.RegisterCloser(producer)
-	return producer
+	cr.onceOrderProducer.Do(func() {
+		producer, err := kafkaout.NewOrderProducer([]string{cr.configs.KafkaHost}, cr.configs.KafkaOrderChangedTopic,
+			cr.configs.KafkaOrderChangedContentType)
+		if err != nil {
+			log.Fatalf("cannot create OrderProducer: %v", err)
+		}
+		cr.RegisterCloser(producer)
+		cr.orderProducer = producer
+	})
+	return cr.orderProducer
 }
 
 func (cr *CompositionRoot) NewEventRegistry() outbox.EventRegistry {
@@ -171,10 +452,22 @@ func (cr *CompositionRoot) NewEventRegistry() outbox.EventRegistry {
 	if err != nil {
 		log.Fatalf("cannot create EventRegistry: %v", err)
 	}
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.CreatedDomainEvent{}))
+	if err != nil {
+		log.Fatalf("cannot register domain event: %v", err)
+	}
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.AssignedDomainEvent{}))
+	if err != nil {
+		log.Fatalf("cannot register domain event: %v", err)
+	}
 	err = registry.RegisterDomainEvent(reflect.TypeOf(order.CompletedDomainEvent{}))
 	if err != nil {
 		log.Fatalf("cannot register domain event: %v", err)
 	}
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.CancelledDomainEvent{}))
+	if err != nil {
+		log.Fatalf("cannot register domain event: %v", err)
+	}
 	return registry
 }
 
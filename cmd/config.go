@@ -1,5 +1,7 @@
 package cmd
 
+const assignOrdersBatchMode = "batch"
+
 type Config struct {
 	HttpPort                  string
 	DbHost                    string
@@ -13,4 +15,34 @@ type Config struct {
 	KafkaConsumerGroup        string
 	KafkaBasketConfirmedTopic string
 	KafkaOrderChangedTopic    string
+
+	// Формат событий OrderStatusChanged: "application/json" (по умолчанию) или "application/x-protobuf"
+	KafkaOrderChangedContentType string
+
+	// Обработка сбоев BasketConfirmed: куда отправлять непрошедшие сообщения, сколько раз повторять
+	// и с какой начальной паузой (например, "500ms"). Пустые значения - политика по умолчанию
+	KafkaBasketConfirmedDlqTopic string
+	KafkaConsumerMaxAttempts     string
+	KafkaConsumerRetryBackoff    string
+
+	// Часовой пояс, в котором заданы часы периода доставки BasketConfirmed, например "Europe/Moscow".
+	// Пустое значение - UTC
+	DeliveryTimezone string
+
+	DispatchStrategy string
+	AssignOrdersMode string
+
+	// Как курьеры ездят к заказам: grid - по клеткам сетки, geo - по координатам
+	MovementModel string
+
+	// Зона и границы карты города, пустые значения - историческая карта 10x10.
+	// Зона сохраняется вместе с точками, поэтому ее не меняют, пока в базе есть курьеры и заказы этой карты
+	MapZone string
+	MapMinX string
+	MapMinY string
+	MapMaxX string
+	MapMaxY string
+
+	// Перекрытые клетки карты через запятую, например "3:4,3:5"
+	MapBlockedCells string
 }
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"net/http"
+)
+
+func (s *Server) CancelOrder(c echo.Context, orderId openapi_types.UUID) error {
+	var cancelOrder servers.CancelOrder
+	if err := c.Bind(&cancelOrder); err != nil {
+		return problems.NewBadRequest("invalid JSON body: " + err.Error())
+	}
+
+	cancelOrderCommand, err := commands.NewCancelOrderCommand(orderId, cancelOrder.Reason)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.cancelOrderCommandHandler.Handle(c.Request().Context(), cancelOrderCommand)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return problems.NewNotFound(err.Error())
+		}
+		return problems.NewConflict(err.Error(), "/")
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"net/http"
+)
+
+func (s *Server) UpdateCourier(c echo.Context, courierId openapi_types.UUID) error {
+	var request servers.UpdateCourier
+	if err := c.Bind(&request); err != nil {
+		return problems.NewBadRequest("invalid JSON body: " + err.Error())
+	}
+
+	var transport *courier.Transport
+	if request.Transport != nil {
+		newTransport, err := courier.TransportByName(string(*request.Transport))
+		if err != nil {
+			return problems.NewBadRequest(err.Error())
+		}
+		transport = &newTransport
+	}
+
+	updateCourierCommand, err := commands.NewUpdateCourierCommand(courierId, request.Name, transport)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.updateCourierCommandHandler.Handle(c.Request().Context(), updateCourierCommand)
+	if err != nil {
+		return courierCommandProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
+
+func (s *Server) DeactivateCourier(c echo.Context, courierId openapi_types.UUID) error {
+	deactivateCourierCommand, err := commands.NewDeactivateCourierCommand(courierId)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.deactivateCourierCommandHandler.Handle(c.Request().Context(), deactivateCourierCommand)
+	if err != nil {
+		return courierCommandProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
+
+func (s *Server) AddStoragePlace(c echo.Context, courierId openapi_types.UUID) error {
+	var request servers.NewStoragePlace
+	if err := c.Bind(&request); err != nil {
+		return problems.NewBadRequest("invalid JSON body: " + err.Error())
+	}
+
+	addStoragePlaceCommand, err := commands.NewAddStoragePlaceCommand(courierId, request.Name, request.TotalVolume)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.addStoragePlaceCommandHandler.Handle(c.Request().Context(), addStoragePlaceCommand)
+	if err != nil {
+		return courierCommandProblem(err)
+	}
+
+	return c.JSON(http.StatusCreated, nil)
+}
+
+func (s *Server) RemoveStoragePlace(c echo.Context, courierId openapi_types.UUID, storagePlaceId openapi_types.UUID) error {
+	removeStoragePlaceCommand, err := commands.NewRemoveStoragePlaceCommand(courierId, storagePlaceId)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.removeStoragePlaceCommandHandler.Handle(c.Request().Context(), removeStoragePlaceCommand)
+	if err != nil {
+		return courierCommandProblem(err)
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
+
+func courierCommandProblem(err error) error {
+	if errors.Is(err, errs.ErrObjectNotFound) {
+		return problems.NewNotFound(err.Error())
+	}
+	if errors.Is(err, errs.ErrValueIsRequired) || errors.Is(err, errs.ErrValueIsInvalid) {
+		return problems.NewBadRequest(err.Error())
+	}
+	return problems.NewConflict(err.Error(), "/")
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"net/http"
+)
+
+func (s *Server) StartCourierShift(c echo.Context, courierId openapi_types.UUID) error {
+	return s.changeCourierShift(c, courierId, commands.ShiftActionStart)
+}
+
+func (s *Server) EndCourierShift(c echo.Context, courierId openapi_types.UUID) error {
+	return s.changeCourierShift(c, courierId, commands.ShiftActionEnd)
+}
+
+func (s *Server) StartCourierBreak(c echo.Context, courierId openapi_types.UUID) error {
+	return s.changeCourierShift(c, courierId, commands.ShiftActionStartBreak)
+}
+
+func (s *Server) EndCourierBreak(c echo.Context, courierId openapi_types.UUID) error {
+	return s.changeCourierShift(c, courierId, commands.ShiftActionEndBreak)
+}
+
+func (s *Server) changeCourierShift(c echo.Context, courierId openapi_types.UUID, action commands.ShiftAction) error {
+	changeCourierShiftCommand, err := commands.NewChangeCourierShiftCommand(courierId, action)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	err = s.changeCourierShiftCommandHandler.Handle(c.Request().Context(), changeCourierShiftCommand)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return problems.NewNotFound(err.Error())
+		}
+		return problems.NewConflict(err.Error(), "/")
+	}
+
+	return c.JSON(http.StatusOK, nil)
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"delivery/internal/adapters/in/http/problems"
 	"delivery/internal/core/application/usecases/commands"
+	courierdomain "delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/generated/servers"
 	"delivery/internal/pkg/errs"
 	"errors"
@@ -16,7 +18,39 @@ func (s *Server) CreateCourier(c echo.Context) error {
 		return problems.NewBadRequest("invalid JSON body: " + err.Error())
 	}
 
-	createCourierCommand, err := commands.NewCreateCourierCommand(courier.Name, courier.Speed)
+	transport, err := courierdomain.TransportByName(string(courier.Transport))
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	var location *kernel.Location
+	if courier.Location != nil {
+		startLocation, err := s.grid.NewLocation(courier.Location.X, courier.Location.Y)
+		if err != nil {
+			return problems.NewBadRequest(err.Error())
+		}
+		location = &startLocation
+	}
+	var coordinate kernel.GeoCoordinate
+	if courier.Coordinate != nil {
+		startCoordinate, err := kernel.NewGeoCoordinate(courier.Coordinate.Latitude, courier.Coordinate.Longitude)
+		if err != nil {
+			return problems.NewBadRequest(err.Error())
+		}
+		coordinate = startCoordinate
+	}
+	var storagePlaces []commands.CourierStoragePlace
+	if courier.StoragePlaces != nil {
+		for _, storagePlace := range *courier.StoragePlaces {
+			storagePlaces = append(storagePlaces, commands.CourierStoragePlace{
+				Name:        storagePlace.Name,
+				TotalVolume: storagePlace.TotalVolume,
+			})
+		}
+	}
+
+	createCourierCommand, err := commands.NewCreateCourierCommand(courier.Name, transport, location, coordinate,
+		storagePlaces)
 	if err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
@@ -3,26 +3,66 @@ package http
 import (
 	"delivery/internal/adapters/in/http/problems"
 	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
 	"delivery/internal/pkg/errs"
 	"errors"
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"time"
 )
 
 func (s *Server) CreateOrder(c echo.Context) error {
-	createOrderCommand, err := commands.NewCreateOrderCommand(uuid.New(), "Несуществующая", 5)
+	var newOrder servers.NewOrder
+	if err := c.Bind(&newOrder); err != nil {
+		return problems.NewBadRequest("invalid JSON body: " + err.Error())
+	}
+
+	address := commands.OrderAddress{
+		Country:   newOrder.Address.Country,
+		City:      newOrder.Address.City,
+		Street:    newOrder.Address.Street,
+		House:     newOrder.Address.House,
+		Apartment: newOrder.Address.Apartment,
+	}
+	createOrderCommand, err := commands.NewCreateOrderCommand(newOrder.BasketId, address, nil, newOrder.Volume,
+		time.Time{}, time.Time{})
 	if err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
 
+	// Повтор запроса с тем же basketId возвращает уже созданный заказ со статусом 200
+	query := queries.GetOrderQuery{OrderID: createOrderCommand.OrderID}
+	status := http.StatusCreated
+	if _, err := s.getOrderQueryHandler.Handle(query); err == nil {
+		status = http.StatusOK
+	} else if !errors.Is(err, errs.ErrObjectNotFound) {
+		return err
+	}
+
 	err = s.createOrderCommandHandler.Handle(c.Request().Context(), createOrderCommand)
 	if err != nil {
+		if errors.Is(err, commands.ErrOrderAlreadyExists) {
+			return problems.NewConflict("order-already-exists",
+				"order with basketId "+newOrder.BasketId.String()+" already exists with a different payload")
+		}
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return problems.NewNotFound(err.Error())
 		}
 		return problems.NewConflict(err.Error(), "/")
 	}
 
-	return c.JSON(http.StatusOK, nil)
+	response, err := s.getOrderQueryHandler.Handle(query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(status, servers.CreatedOrder{
+		Id: response.ID,
+		Location: servers.Location{
+			X: response.Location.X,
+			Y: response.Location.Y,
+		},
+		Status: response.Status,
+	})
 }
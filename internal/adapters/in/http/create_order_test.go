@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type fakeCreateOrderCommandHandler struct {
+	err     error
+	command *commands.CreateOrderCommand
+}
+
+func (h *fakeCreateOrderCommandHandler) Handle(_ context.Context, command *commands.CreateOrderCommand) error {
+	h.command = command
+	return h.err
+}
+
+// fakeGetOrderQueryHandler находит заказ, если он был до запроса или его создал обработчик команды
+type fakeGetOrderQueryHandler struct {
+	response      queries.GetOrderResponse
+	existing      bool
+	createHandler *fakeCreateOrderCommandHandler
+}
+
+func (h *fakeGetOrderQueryHandler) Handle(query queries.GetOrderQuery) (queries.GetOrderResponse, error) {
+	if !h.existing && (h.createHandler.command == nil || h.createHandler.err != nil) {
+		return queries.GetOrderResponse{}, errs.NewObjectNotFoundError("order", query.OrderID)
+	}
+	response := h.response
+	response.ID = query.OrderID
+	return response, nil
+}
+
+func newCreateOrderServer(createHandler *fakeCreateOrderCommandHandler, existing bool) *Server {
+	return &Server{
+		createOrderCommandHandler: createHandler,
+		getOrderQueryHandler: &fakeGetOrderQueryHandler{
+			response: queries.GetOrderResponse{
+				Status:   "Created",
+				Location: queries.LocationResponse{X: 3, Y: 7},
+			},
+			existing:      existing,
+			createHandler: createHandler,
+		},
+	}
+}
+
+func Test_CreateOrder_BindsRequestAndReturnsCreatedOrder(t *testing.T) {
+	createHandler := &fakeCreateOrderCommandHandler{}
+	server := newCreateOrderServer(createHandler, false)
+	basketID := uuid.New()
+	body := `{"basketId":"` + basketID.String() + `","volume":5,"address":{"country":"Россия","city":"Москва",` +
+		`"street":"Тверская","house":"1","apartment":"10"}}`
+
+	recorder := serve(server, http.MethodPost, "/api/v1/orders", body)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	var created servers.CreatedOrder
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &created))
+	assert.Equal(t, servers.CreatedOrder{Id: basketID, Location: servers.Location{X: 3, Y: 7}, Status: "Created"},
+		created)
+
+	if assert.NotNil(t, createHandler.command) {
+		assert.Equal(t, basketID, createHandler.command.OrderID)
+		assert.Equal(t, 5, createHandler.command.Volume)
+		assert.Equal(t, commands.OrderAddress{
+			Country: "Россия", City: "Москва", Street: "Тверская", House: "1", Apartment: "10",
+		}, createHandler.command.Address)
+		assert.Nil(t, createHandler.command.Inbox)
+	}
+}
+
+func Test_CreateOrder_ReturnsExistingOrderForRepeatedRequest(t *testing.T) {
+	createHandler := &fakeCreateOrderCommandHandler{}
+	server := newCreateOrderServer(createHandler, true)
+	basketID := uuid.New()
+	body := `{"basketId":"` + basketID.String() + `","volume":5,"address":{"street":"Тверская"}}`
+
+	recorder := serve(server, http.MethodPost, "/api/v1/orders", body)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var created servers.CreatedOrder
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &created))
+	assert.Equal(t, servers.CreatedOrder{Id: basketID, Location: servers.Location{X: 3, Y: 7}, Status: "Created"},
+		created)
+	assert.NotNil(t, createHandler.command)
+}
+
+func Test_CreateOrder_ReturnsConflictForDuplicateBasketWithDifferentPayload(t *testing.T) {
+	server := newCreateOrderServer(&fakeCreateOrderCommandHandler{err: commands.ErrOrderAlreadyExists}, true)
+	body := `{"basketId":"` + uuid.NewString() + `","volume":5,"address":{"street":"Тверская"}}`
+
+	recorder := serve(server, http.MethodPost, "/api/v1/orders", body)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already exists")
+}
+
+func Test_CreateOrder_RejectsInvalidRequest(t *testing.T) {
+	tests := map[string]string{
+		"not json":     `{`,
+		"no street":    `{"basketId":"` + uuid.NewString() + `","volume":5,"address":{"city":"Москва"}}`,
+		"zero volume":  `{"basketId":"` + uuid.NewString() + `","volume":0,"address":{"street":"Тверская"}}`,
+		"nil basketId": `{"basketId":"` + uuid.Nil.String() + `","volume":5,"address":{"street":"Тверская"}}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			createHandler := &fakeCreateOrderCommandHandler{}
+			server := newCreateOrderServer(createHandler, false)
+
+			recorder := serve(server, http.MethodPost, "/api/v1/orders", body)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.Nil(t, createHandler.command)
+		})
+	}
+}
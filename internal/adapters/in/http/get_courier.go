@@ -0,0 +1,65 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"net/http"
+)
+
+func (s *Server) GetCourier(c echo.Context, courierId openapi_types.UUID) error {
+	query := queries.GetCourierQuery{CourierID: courierId}
+
+	response, err := s.getCourierQueryHandler.Handle(query)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return problems.NewNotFound(err.Error())
+		}
+		if errors.Is(err, errs.ErrValueIsRequired) || errors.Is(err, errs.ErrValueIsInvalid) {
+			return problems.NewBadRequest(err.Error())
+		}
+		return err
+	}
+
+	courier := servers.CourierDetails{
+		Id:        response.ID,
+		Name:      response.Name,
+		Speed:     response.Speed,
+		Transport: response.Transport,
+		Status:    response.Status,
+		Location: servers.Location{
+			X: response.Location.X,
+			Y: response.Location.Y,
+		},
+		StoragePlaces: make([]servers.StoragePlace, 0, len(response.StoragePlaces)),
+		Orders:        make([]servers.CarriedOrder, 0, len(response.Orders)),
+	}
+	for _, storagePlace := range response.StoragePlaces {
+		courier.StoragePlaces = append(courier.StoragePlaces, servers.StoragePlace{
+			Id:          storagePlace.ID,
+			Name:        storagePlace.Name,
+			TotalVolume: storagePlace.TotalVolume,
+			UsedVolume:  storagePlace.UsedVolume,
+			FreeVolume:  storagePlace.FreeVolume,
+		})
+	}
+	for _, o := range response.Orders {
+		courier.Orders = append(courier.Orders, servers.CarriedOrder{
+			Id:             o.ID,
+			StoragePlaceId: o.StoragePlaceID,
+			Volume:         o.Volume,
+			Location: servers.Location{
+				X: o.Location.X,
+				Y: o.Location.Y,
+			},
+			Steps: o.Steps,
+			Eta:   o.ETA,
+		})
+	}
+
+	return c.JSON(http.StatusOK, courier)
+}
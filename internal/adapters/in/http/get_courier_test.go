@@ -0,0 +1,107 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeGetCourierQueryHandler struct {
+	response queries.GetCourierResponse
+	err      error
+}
+
+func (h *fakeGetCourierQueryHandler) Handle(query queries.GetCourierQuery) (queries.GetCourierResponse, error) {
+	if query.CourierID == uuid.Nil {
+		return queries.GetCourierResponse{}, errs.NewValueIsRequiredError("courierID")
+	}
+	return h.response, h.err
+}
+
+// serve прогоняет запрос через echo с теми же обработчиками ошибок, что и в сервисе
+func serve(server *Server, method string, path string, body string) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.HTTPErrorHandler = problems.NewHTTPErrorHandler(e)
+	servers.RegisterHandlers(e, server)
+
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_GetCourier_ReturnsCourier(t *testing.T) {
+	courierID := uuid.New()
+	server := &Server{getCourierQueryHandler: &fakeGetCourierQueryHandler{
+		response: queries.GetCourierResponse{ID: courierID, Name: "Пешеход", Speed: 1, Transport: "pedestrian"},
+	}}
+
+	recorder := serve(server, http.MethodGet, "/api/v1/couriers/"+courierID.String(), "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var courier servers.CourierDetails
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &courier))
+	assert.Equal(t, courierID, courier.Id)
+	assert.Equal(t, "Пешеход", courier.Name)
+}
+
+func Test_GetCourier_OmitsEtaForUnreachableOrder(t *testing.T) {
+	courierID := uuid.New()
+	steps, eta := 4.0, time.Date(2025, 1, 1, 12, 0, 40, 0, time.UTC)
+	server := &Server{getCourierQueryHandler: &fakeGetCourierQueryHandler{
+		response: queries.GetCourierResponse{ID: courierID, Orders: []queries.CarriedOrderResponse{
+			{ID: uuid.New(), Steps: &steps, ETA: &eta},
+			{ID: uuid.New()},
+		}},
+	}}
+
+	recorder := serve(server, http.MethodGet, "/api/v1/couriers/"+courierID.String(), "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var courier struct {
+		Orders []map[string]any `json:"orders"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &courier))
+	assert.Len(t, courier.Orders, 2)
+	assert.Equal(t, 4.0, courier.Orders[0]["steps"])
+	assert.Equal(t, "2025-01-01T12:00:40Z", courier.Orders[0]["eta"])
+	assert.NotContains(t, courier.Orders[1], "steps")
+	assert.NotContains(t, courier.Orders[1], "eta")
+}
+
+func Test_GetCourier_MapsErrorsToStatusCodes(t *testing.T) {
+	tests := map[string]struct {
+		courierID string
+		err       error
+		expected  int
+	}{
+		"nil id":        {courierID: uuid.Nil.String(), expected: http.StatusBadRequest},
+		"malformed id":  {courierID: "42", expected: http.StatusBadRequest},
+		"invalid value": {courierID: uuid.NewString(), err: errs.NewValueIsInvalidError("courierID"), expected: http.StatusBadRequest},
+		"not found":     {courierID: uuid.NewString(), err: errs.NewObjectNotFoundError("courier", nil), expected: http.StatusNotFound},
+		"unexpected":    {courierID: uuid.NewString(), err: errors.New("connection reset"), expected: http.StatusInternalServerError},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := &Server{getCourierQueryHandler: &fakeGetCourierQueryHandler{err: tt.err}}
+
+			recorder := serve(server, http.MethodGet, "/api/v1/couriers/"+tt.courierID, "")
+
+			assert.Equal(t, tt.expected, recorder.Code)
+		})
+	}
+}
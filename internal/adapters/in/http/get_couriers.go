@@ -10,17 +10,43 @@ import (
 	"net/http"
 )
 
-func (s *Server) GetCouriers(c echo.Context) error {
-	query := queries.GetAllCouriersQuery{}
+func (s *Server) GetCouriers(c echo.Context, params servers.GetCouriersParams) error {
+	query := queries.GetAllCouriersQuery{
+		Busy:     params.Busy,
+		MinSpeed: params.MinSpeed,
+		MaxSpeed: params.MaxSpeed,
+	}
+	if params.MinX != nil || params.MinY != nil || params.MaxX != nil || params.MaxY != nil {
+		if params.MinX == nil || params.MinY == nil || params.MaxX == nil || params.MaxY == nil {
+			return problems.NewBadRequest("area filter requires minX, minY, maxX and maxY")
+		}
+		query.Area = &queries.LocationArea{MinX: *params.MinX, MinY: *params.MinY, MaxX: *params.MaxX, MaxY: *params.MaxY}
+	}
+	if params.SortBy != nil {
+		query.SortBy = queries.CourierSortField(*params.SortBy)
+	}
+	if params.SortOrder != nil {
+		query.SortOrder = queries.SortOrder(*params.SortOrder)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
 
 	response, err := s.getAllCouriersQueryHandler.Handle(query)
 	if err != nil {
+		if errors.Is(err, errs.ErrValueIsInvalid) || errors.Is(err, errs.ErrValueIsOutOfRange) {
+			return problems.NewBadRequest(err.Error())
+		}
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
 		}
+		return err
 	}
 
-	var couriers []servers.Courier
+	couriers := make([]servers.Courier, 0, len(response.Couriers))
 	for _, courier := range response.Couriers {
 		location := servers.Location{
 			X: courier.Location.X,
@@ -28,11 +54,18 @@ func (s *Server) GetCouriers(c echo.Context) error {
 		}
 
 		var courier = servers.Courier{
-			Id:       courier.ID,
-			Name:     courier.Name,
-			Location: location,
+			Id:        courier.ID,
+			Name:      courier.Name,
+			Speed:     courier.Speed,
+			Transport: courier.Transport,
+			Status:    courier.Status,
+			Location:  location,
 		}
 		couriers = append(couriers, courier)
 	}
+
+	if response.NextCursor != "" {
+		c.Response().Header().Set(nextCursorHeader, response.NextCursor)
+	}
 	return c.JSON(http.StatusOK, couriers)
 }
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"delivery/internal/adapters/in/http/problems"
+	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/generated/servers"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"net/http"
+)
+
+func (s *Server) GetOrder(c echo.Context, orderId openapi_types.UUID) error {
+	query := queries.GetOrderQuery{OrderID: orderId}
+
+	response, err := s.getOrderQueryHandler.Handle(query)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return problems.NewNotFound(err.Error())
+		}
+		return err
+	}
+
+	order := servers.OrderDetails{
+		Id:        response.ID,
+		Status:    response.Status,
+		CourierId: response.CourierID,
+		Volume:    response.Volume,
+		Location: servers.Location{
+			X: response.Location.X,
+			Y: response.Location.Y,
+		},
+		Address: mapAddressResponse(response.Address),
+		Items:   mapItemResponses(response.Items),
+		IsLate:  response.Late,
+		History: make([]servers.StatusHistoryEntry, 0, len(response.History)),
+	}
+	if response.DeliveryWindow.From != nil && response.DeliveryWindow.To != nil {
+		order.DeliveryWindow = &servers.DeliveryWindow{
+			From: *response.DeliveryWindow.From,
+			To:   *response.DeliveryWindow.To,
+		}
+	}
+	if response.CancelReason != "" {
+		order.CancelReason = &response.CancelReason
+	}
+	for _, entry := range response.History {
+		order.History = append(order.History, servers.StatusHistoryEntry{
+			Status:    entry.Status,
+			ChangedAt: entry.ChangedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
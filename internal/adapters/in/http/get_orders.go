@@ -3,6 +3,7 @@ package http
 import (
 	"delivery/internal/adapters/in/http/problems"
 	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/core/domain/model/order"
 	"delivery/internal/generated/servers"
 	"delivery/internal/pkg/errs"
 	"errors"
@@ -10,28 +11,91 @@ import (
 	"net/http"
 )
 
-func (s *Server) GetOrders(c echo.Context) error {
-	query := queries.GetNotCompletedOrdersQuery{}
+// nextCursorHeader - заголовок с курсором следующей страницы списков
+const nextCursorHeader = "X-Next-Cursor"
+
+func (s *Server) GetOrders(c echo.Context, params servers.GetOrdersParams) error {
+	query := queries.GetNotCompletedOrdersQuery{
+		CourierID:     params.CourierId,
+		CreatedAfter:  params.CreatedFrom,
+		CreatedBefore: params.CreatedTo,
+	}
+	if params.Status != nil {
+		status := order.Status(*params.Status)
+		query.Status = &status
+	}
+	if params.SortBy != nil {
+		query.SortBy = queries.OrderSortField(*params.SortBy)
+	}
+	if params.SortOrder != nil {
+		query.SortOrder = queries.SortOrder(*params.SortOrder)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
 
 	response, err := s.getNotCompletedOrdersQueryHandler.Handle(query)
 	if err != nil {
+		if errors.Is(err, errs.ErrValueIsInvalid) || errors.Is(err, errs.ErrValueIsOutOfRange) {
+			return problems.NewBadRequest(err.Error())
+		}
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return c.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
 		}
+		return err
 	}
 
-	var orders []servers.Order
-	for _, courier := range response.Orders {
+	orders := make([]servers.Order, 0, len(response.Orders))
+	for _, o := range response.Orders {
 		location := servers.Location{
-			X: courier.Location.X,
-			Y: courier.Location.Y,
+			X: o.Location.X,
+			Y: o.Location.Y,
 		}
 
-		var courier = servers.Order{
-			Id:       courier.ID,
-			Location: location,
-		}
-		orders = append(orders, courier)
+		orders = append(orders, servers.Order{
+			Id:        o.ID,
+			Status:    o.Status,
+			CourierId: o.CourierID,
+			Volume:    o.Volume,
+			CreatedAt: o.CreatedAt,
+			Location:  location,
+			Address:   mapAddressResponse(o.Address),
+			Items:     mapItemResponses(o.Items),
+		})
+	}
+
+	if response.NextCursor != "" {
+		c.Response().Header().Set(nextCursorHeader, response.NextCursor)
 	}
 	return c.JSON(http.StatusOK, orders)
 }
+
+func mapAddressResponse(address queries.AddressResponse) *servers.Address {
+	if address.IsEmpty() {
+		return nil
+	}
+	return &servers.Address{
+		Country:   address.Country,
+		City:      address.City,
+		Street:    address.Street,
+		House:     address.House,
+		Apartment: address.Apartment,
+	}
+}
+
+func mapItemResponses(items []queries.ItemResponse) []servers.Item {
+	result := make([]servers.Item, 0, len(items))
+	for _, item := range items {
+		result = append(result, servers.Item{
+			Id:       item.ID,
+			GoodId:   item.GoodID,
+			Title:    item.Title,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+		})
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package problems
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type problem interface {
+	error
+	WriteResponse(w http.ResponseWriter)
+}
+
+// NewHTTPErrorHandler отдает ошибки из этого пакета клиенту как ProblemDetails с их статусом,
+// остальные ошибки обрабатывает стандартный обработчик echo
+func NewHTTPErrorHandler(e *echo.Echo) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		var p problem
+		if errors.As(err, &p) && !c.Response().Committed {
+			p.WriteResponse(c.Response())
+			return
+		}
+		e.DefaultHTTPErrorHandler(err, c)
+	}
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+func (s *Server) ReplayDeadLetters(c echo.Context) error {
+	replayed, err := s.deadLetterReplayer.Replay(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, servers.ReplayedDeadLetters{Replayed: replayed})
+}
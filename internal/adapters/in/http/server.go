@@ -3,23 +3,50 @@ package http
 import (
 	"delivery/internal/core/application/usecases/commands"
 	"delivery/internal/core/application/usecases/queries"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
 )
 
 type Server struct {
-	createOrderCommandHandler   commands.CreateOrderCommandHandler
-	createCourierCommandHandler commands.CreateCourierCommandHandler
+	createOrderCommandHandler        commands.CreateOrderCommandHandler
+	createCourierCommandHandler      commands.CreateCourierCommandHandler
+	cancelOrderCommandHandler        commands.CancelOrderCommandHandler
+	changeCourierShiftCommandHandler commands.ChangeCourierShiftCommandHandler
+	updateCourierCommandHandler      commands.UpdateCourierCommandHandler
+	deactivateCourierCommandHandler  commands.DeactivateCourierCommandHandler
+	addStoragePlaceCommandHandler    commands.AddStoragePlaceCommandHandler
+	removeStoragePlaceCommandHandler commands.RemoveStoragePlaceCommandHandler
 
 	getAllCouriersQueryHandler        queries.GetAllCouriersQueryHandler
 	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler
+	getOrderQueryHandler              queries.GetOrderQueryHandler
+	getCourierQueryHandler            queries.GetCourierQueryHandler
+
+	deadLetterReplayer ports.DeadLetterReplayer
+
+	// grid - карта города, на ней проверяются координаты из запросов
+	grid kernel.Grid
 }
 
 func NewServer(
 	createOrderCommandHandler commands.CreateOrderCommandHandler,
 	createCourierCommandHandler commands.CreateCourierCommandHandler,
+	cancelOrderCommandHandler commands.CancelOrderCommandHandler,
+	changeCourierShiftCommandHandler commands.ChangeCourierShiftCommandHandler,
+	updateCourierCommandHandler commands.UpdateCourierCommandHandler,
+	deactivateCourierCommandHandler commands.DeactivateCourierCommandHandler,
+	addStoragePlaceCommandHandler commands.AddStoragePlaceCommandHandler,
+	removeStoragePlaceCommandHandler commands.RemoveStoragePlaceCommandHandler,
 
 	getAllCouriersQueryHandler queries.GetAllCouriersQueryHandler,
 	getNotCompletedOrdersQueryHandler queries.GetNotCompletedOrdersQueryHandler,
+	getOrderQueryHandler queries.GetOrderQueryHandler,
+	getCourierQueryHandler queries.GetCourierQueryHandler,
+
+	deadLetterReplayer ports.DeadLetterReplayer,
+
+	grid kernel.Grid,
 ) (*Server, error) {
 	if createOrderCommandHandler == nil {
 		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
@@ -27,16 +54,56 @@ func NewServer(
 	if createCourierCommandHandler == nil {
 		return nil, errs.NewValueIsRequiredError("createCourierCommandHandler")
 	}
+	if cancelOrderCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("cancelOrderCommandHandler")
+	}
+	if changeCourierShiftCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("changeCourierShiftCommandHandler")
+	}
+	if updateCourierCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("updateCourierCommandHandler")
+	}
+	if deactivateCourierCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("deactivateCourierCommandHandler")
+	}
+	if addStoragePlaceCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("addStoragePlaceCommandHandler")
+	}
+	if removeStoragePlaceCommandHandler == nil {
+		return nil, errs.NewValueIsRequiredError("removeStoragePlaceCommandHandler")
+	}
 	if getAllCouriersQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("getAllCouriersQueryHandler")
 	}
 	if getNotCompletedOrdersQueryHandler == nil {
 		return nil, errs.NewValueIsRequiredError("getNotCompletedOrdersQueryHandler")
 	}
+	if getOrderQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getOrderQueryHandler")
+	}
+	if getCourierQueryHandler == nil {
+		return nil, errs.NewValueIsRequiredError("getCourierQueryHandler")
+	}
+	if deadLetterReplayer == nil {
+		return nil, errs.NewValueIsRequiredError("deadLetterReplayer")
+	}
+	if grid.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("grid")
+	}
 	return &Server{
 		createOrderCommandHandler:         createOrderCommandHandler,
 		createCourierCommandHandler:       createCourierCommandHandler,
+		cancelOrderCommandHandler:         cancelOrderCommandHandler,
+		changeCourierShiftCommandHandler:  changeCourierShiftCommandHandler,
+		updateCourierCommandHandler:       updateCourierCommandHandler,
+		deactivateCourierCommandHandler:   deactivateCourierCommandHandler,
+		addStoragePlaceCommandHandler:     addStoragePlaceCommandHandler,
+		removeStoragePlaceCommandHandler:  removeStoragePlaceCommandHandler,
 		getAllCouriersQueryHandler:        getAllCouriersQueryHandler,
 		getNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+		getOrderQueryHandler:              getOrderQueryHandler,
+		getCourierQueryHandler:            getCourierQueryHandler,
+		deadLetterReplayer:                deadLetterReplayer,
+		grid:                              grid,
 	}, nil
 }
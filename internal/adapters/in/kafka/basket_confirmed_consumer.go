@@ -4,12 +4,16 @@ import (
 	"context"
 	"delivery/internal/core/application/usecases/commands"
 	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/codec"
 	"delivery/internal/pkg/errs"
-	"encoding/json"
+	"delivery/internal/pkg/inbox"
+	"errors"
 	"fmt"
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 	"log"
+	"strings"
+	"time"
 )
 
 type BasketConfirmedConsumer interface {
@@ -24,6 +28,10 @@ type basketConfirmedConsumer struct {
 	topic                     string
 	consumerGroup             sarama.ConsumerGroup
 	createOrderCommandHandler commands.CreateOrderCommandHandler
+	deadLetterQueue           DeadLetterQueue
+	retryPolicy               RetryPolicy
+	deliveryTimezone          *time.Location
+	sleep                     func(context.Context, time.Duration) error
 	ctx                       context.Context
 	cancel                    context.CancelFunc
 }
@@ -33,6 +41,9 @@ func NewBasketConfirmedConsumer(
 	group string,
 	topic string,
 	createOrderCommandHandler commands.CreateOrderCommandHandler,
+	deadLetterQueue DeadLetterQueue,
+	retryPolicy RetryPolicy,
+	deliveryTimezone *time.Location,
 ) (BasketConfirmedConsumer, error) {
 	if len(brokers) == 0 {
 		return nil, errs.NewValueIsRequiredError("brokers")
@@ -46,6 +57,15 @@ func NewBasketConfirmedConsumer(
 	if createOrderCommandHandler == nil {
 		return nil, errs.NewValueIsRequiredError("createOrderCommandHandler")
 	}
+	if deadLetterQueue == nil {
+		return nil, errs.NewValueIsRequiredError("deadLetterQueue")
+	}
+	if retryPolicy.MaxAttempts <= 0 {
+		return nil, errs.NewValueIsRequiredError("retryPolicy")
+	}
+	if deliveryTimezone == nil {
+		return nil, errs.NewValueIsRequiredError("deliveryTimezone")
+	}
 
 	saramaCfg := sarama.NewConfig()
 	saramaCfg.Version = sarama.V3_4_0_0
@@ -63,6 +83,10 @@ func NewBasketConfirmedConsumer(
 		topic:                     topic,
 		consumerGroup:             consumerGroup,
 		createOrderCommandHandler: createOrderCommandHandler,
+		deadLetterQueue:           deadLetterQueue,
+		retryPolicy:               retryPolicy,
+		deliveryTimezone:          deliveryTimezone,
+		sleep:                     sleep,
 		ctx:                       ctx,
 		cancel:                    cancel,
 	}, nil
@@ -70,7 +94,11 @@ func NewBasketConfirmedConsumer(
 
 func (c *basketConfirmedConsumer) Close() error {
 	c.cancel()
-	return c.consumerGroup.Close()
+	err := c.consumerGroup.Close()
+	if dlqErr := c.deadLetterQueue.Close(); err == nil {
+		err = dlqErr
+	}
+	return err
 }
 
 func (c *basketConfirmedConsumer) Consume() error {
@@ -92,31 +120,172 @@ func (c *basketConfirmedConsumer) Setup(_ sarama.ConsumerGroupSession) error   {
 func (c *basketConfirmedConsumer) Cleanup(_ sarama.ConsumerGroupSession) error { return nil }
 func (c *basketConfirmedConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
-		ctx := context.Background()
 		fmt.Printf("Received: topic = %s, partition = %d, offset = %d, key = %s, value = %s\n",
 			message.Topic, message.Partition, message.Offset, string(message.Key), string(message.Value))
 
-		var event basketconfirmedpb.BasketConfirmedIntegrationEvent
-		if err := json.Unmarshal(message.Value, &event); err != nil {
-			log.Printf("Failed to unmarshal message: %v", err)
-			session.MarkMessage(message, "")
-			continue
+		// Сообщение отмечается прочитанным, только когда оно обработано или отправлено в DLQ.
+		// Иначе сессия завершается и после переподключения сообщение будет прочитано снова
+		if err := c.handleMessage(session.Context(), message); err != nil {
+			log.Printf("Failed to handle message, it will be redelivered: %v", err)
+			return err
 		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
 
-		cmd, err := commands.NewCreateOrderCommand(
-			uuid.MustParse(event.BasketId), event.Address.Street, int(event.Volume),
-		)
-		if err != nil {
-			log.Printf("Failed to create createOrder command: %v", err)
-			session.MarkMessage(message, "")
-			continue
+// handleMessage обрабатывает сообщение: некорректное сразу отклоняется в DLQ, временные ошибки
+// повторяются по retryPolicy, а если попытки кончились - сообщение тоже уходит в DLQ
+func (c *basketConfirmedConsumer) handleMessage(ctx context.Context, message *sarama.ConsumerMessage) (err error) {
+	// Паника на одном сообщении не должна останавливать потребителя и весь сервис вместе с ним.
+	// Открытую транзакцию при панике откатывает сам обработчик команды
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.reject(ctx, message, fmt.Errorf("panic while handling message: %v", r))
+		}
+	}()
+
+	cmd, err := c.toCommand(message)
+	if err != nil {
+		return c.reject(ctx, message, err)
+	}
+
+	var handleErr error
+	for attempt := 1; attempt <= c.retryPolicy.MaxAttempts; attempt++ {
+		handleErr = c.createOrderCommandHandler.Handle(ctx, cmd)
+		if handleErr == nil {
+			return nil
+		}
+		if errors.Is(handleErr, commands.ErrOrderAlreadyExists) {
+			// Заказ по этой корзине уже создан с другими данными, например вручную через HTTP.
+			// Созданный заказ остается как есть - сообщение обработано
+			log.Printf("Order for basket %s already exists with a different payload, skipping message", cmd.OrderID)
+			return nil
+		}
+		if isPermanent(handleErr) {
+			log.Printf("Failed to handle createOrder command, sending to DLQ: %v", handleErr)
+			return c.deadLetterQueue.Send(ctx, message, handleErr, attempt)
+		}
+		if attempt == c.retryPolicy.MaxAttempts {
+			break
 		}
 
-		if err := c.createOrderCommandHandler.Handle(ctx, cmd); err != nil {
-			log.Printf("Failed to handle createOrder command: %v", err)
+		backoff := c.retryPolicy.Backoff(attempt)
+		log.Printf("Failed to handle createOrder command (attempt %d of %d), retrying in %s: %v",
+			attempt, c.retryPolicy.MaxAttempts, backoff, handleErr)
+		if err := c.sleep(ctx, backoff); err != nil {
+			return err
 		}
+	}
 
-		session.MarkMessage(message, "")
+	log.Printf("Retries exhausted, sending to DLQ: %v", handleErr)
+	return c.deadLetterQueue.Send(ctx, message, handleErr, c.retryPolicy.MaxAttempts)
+}
+
+// reject - путь для сообщений, которые не имеет смысла повторять: они уходят в DLQ без попыток обработки
+func (c *basketConfirmedConsumer) reject(ctx context.Context, message *sarama.ConsumerMessage, cause error) error {
+	log.Printf("Rejected message topic = %s, partition = %d, offset = %d: %v",
+		message.Topic, message.Partition, message.Offset, cause)
+	return c.deadLetterQueue.Send(ctx, message, cause, 0)
+}
+
+func (c *basketConfirmedConsumer) toCommand(message *sarama.ConsumerMessage) (*commands.CreateOrderCommand, error) {
+	var event basketconfirmedpb.BasketConfirmedIntegrationEvent
+	if err := codec.Unmarshal(contentType(message), message.Value, &event); err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("message", err)
 	}
-	return nil
+	if err := validateBasketConfirmed(&event); err != nil {
+		return nil, err
+	}
+
+	basketID, err := uuid.Parse(event.GetBasketId())
+	if err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("basketId", err)
+	}
+	items, err := mapItems(event.GetItems())
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryFrom, deliveryTo := deliveryPeriodToTime(event.GetDeliveryPeriod(), time.Now(), c.deliveryTimezone)
+	cmd, err := commands.NewCreateOrderCommand(
+		basketID, mapAddress(event.GetAddress()), items, int(event.Volume),
+		deliveryFrom, deliveryTo,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create createOrder command: %w", err)
+	}
+
+	cmd.Inbox = &inbox.Message{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		BasketID:  cmd.OrderID,
+	}
+	return cmd, nil
+}
+
+// contentType - формат тела из заголовка сообщения, пустая строка если заголовка нет
+func contentType(message *sarama.ConsumerMessage) string {
+	for _, header := range message.Headers {
+		if header != nil && strings.EqualFold(string(header.Key), codec.Header) {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func mapAddress(address *basketconfirmedpb.Address) commands.OrderAddress {
+	return commands.OrderAddress{
+		Country:   address.GetCountry(),
+		City:      address.GetCity(),
+		Street:    address.GetStreet(),
+		House:     address.GetHouse(),
+		Apartment: address.GetApartment(),
+	}
+}
+
+func mapItems(items []*basketconfirmedpb.Item) ([]commands.OrderItem, error) {
+	result := make([]commands.OrderItem, 0, len(items))
+	for _, item := range items {
+		id, err := uuid.Parse(item.GetId())
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("item.id", err)
+		}
+		goodID, err := uuid.Parse(item.GetGoodId())
+		if err != nil {
+			return nil, errs.NewValueIsInvalidErrorWithCause("item.goodId", err)
+		}
+		result = append(result, commands.OrderItem{
+			ID:       id,
+			GoodID:   goodID,
+			Title:    item.GetTitle(),
+			Price:    item.GetPrice(),
+			Quantity: int(item.GetQuantity()),
+		})
+	}
+	return result, nil
+}
+
+// deliveryPeriodToTime переводит период доставки из корзины (часы от и до) в ближайший такой интервал,
+// который еще не закончился. Часы - местное время зоны доставки timezone, поэтому и день отсчитывается
+// по ее часам, а не по UTC. Пустой или некорректный период - доставка без ограничений по времени
+func deliveryPeriodToTime(period *basketconfirmedpb.DeliveryPeriod, now time.Time,
+	timezone *time.Location) (time.Time, time.Time) {
+	if period == nil {
+		return time.Time{}, time.Time{}
+	}
+	fromHour, toHour := int(period.GetFrom()), int(period.GetTo())
+	if fromHour < 0 || toHour > 24 || fromHour >= toHour {
+		return time.Time{}, time.Time{}
+	}
+
+	now = now.In(timezone)
+	year, month, day := now.Date()
+	to := time.Date(year, month, day, toHour, 0, 0, 0, timezone)
+	if !to.After(now) {
+		day++
+		to = time.Date(year, month, day, toHour, 0, 0, 0, timezone)
+	}
+	return time.Date(year, month, day, fromHour, 0, 0, 0, timezone).UTC(), to.UTC()
 }
@@ -0,0 +1,431 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/codec"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+// fakeSession - сессия группы потребителей, которая только запоминает отмеченные сообщения
+type fakeSession struct {
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *fakeSession) Claims() map[string][]int32                       { return nil }
+func (s *fakeSession) MemberID() string                                 { return "member" }
+func (s *fakeSession) GenerationID() int32                              { return 1 }
+func (s *fakeSession) MarkOffset(_ string, _ int32, _ int64, _ string)  {}
+func (s *fakeSession) Commit()                                          {}
+func (s *fakeSession) ResetOffset(_ string, _ int32, _ int64, _ string) {}
+func (s *fakeSession) Context() context.Context                         { return s.ctx }
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg)
+}
+
+type fakeClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func newFakeClaim(messages ...*sarama.ConsumerMessage) *fakeClaim {
+	ch := make(chan *sarama.ConsumerMessage, len(messages))
+	for _, message := range messages {
+		ch <- message
+	}
+	close(ch)
+	return &fakeClaim{messages: ch}
+}
+
+func (c *fakeClaim) Topic() string                            { return "basket.confirmed" }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return int64(len(c.messages)) }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// fakeHandler по очереди возвращает заданные ошибки, после них - успех
+type fakeHandler struct {
+	errs  []error
+	calls int
+	last  *commands.CreateOrderCommand
+}
+
+func (h *fakeHandler) Handle(_ context.Context, cmd *commands.CreateOrderCommand) error {
+	h.calls++
+	h.last = cmd
+	if h.calls <= len(h.errs) {
+		return h.errs[h.calls-1]
+	}
+	return nil
+}
+
+type deadLetter struct {
+	message  *sarama.ConsumerMessage
+	cause    error
+	attempts int
+}
+
+type fakeDeadLetterQueue struct {
+	err     error
+	letters []deadLetter
+}
+
+func (q *fakeDeadLetterQueue) Send(_ context.Context, message *sarama.ConsumerMessage, cause error, attempts int) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.letters = append(q.letters, deadLetter{message: message, cause: cause, attempts: attempts})
+	return nil
+}
+
+func (q *fakeDeadLetterQueue) Close() error { return nil }
+
+func newTestConsumer(handler commands.CreateOrderCommandHandler, dlq DeadLetterQueue) (*basketConfirmedConsumer, *[]time.Duration) {
+	var pauses []time.Duration
+	consumer := &basketConfirmedConsumer{
+		topic:                     "basket.confirmed",
+		createOrderCommandHandler: handler,
+		deadLetterQueue:           dlq,
+		retryPolicy:               RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+		deliveryTimezone:          time.UTC,
+		sleep: func(_ context.Context, d time.Duration) error {
+			pauses = append(pauses, d)
+			return nil
+		},
+	}
+	return consumer, &pauses
+}
+
+func validMessage() *sarama.ConsumerMessage {
+	value := `{"basketId":"` + uuid.NewString() + `","address":{"street":"Тверская"},"Volume":5}`
+	return &sarama.ConsumerMessage{Topic: "basket.confirmed", Partition: 1, Offset: 42, Value: []byte(value)}
+}
+
+func Test_ConsumeClaim_MarksHandledMessage(t *testing.T) {
+	handler := &fakeHandler{}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, pauses := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	message := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	assert.Equal(t, 1, handler.calls)
+	assert.Empty(t, dlq.letters)
+	assert.Empty(t, *pauses)
+}
+
+func Test_ConsumeClaim_DecodesProtobufMessage(t *testing.T) {
+	basketID := uuid.New()
+	value, err := proto.Marshal(&basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: basketID.String(),
+		Address:  &basketconfirmedpb.Address{Street: "Тверская"},
+		Volume:   5,
+	})
+	assert.NoError(t, err)
+	handler := &fakeHandler{}
+	consumer, _ := newTestConsumer(handler, &fakeDeadLetterQueue{})
+	session := &fakeSession{ctx: context.Background()}
+	message := &sarama.ConsumerMessage{
+		Topic: "basket.confirmed", Value: value,
+		Headers: []*sarama.RecordHeader{{Key: []byte("Content-Type"), Value: []byte(codec.Protobuf)}},
+	}
+
+	err = consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, basketID, handler.last.OrderID)
+	assert.Equal(t, "Тверская", handler.last.Address.Street)
+	assert.Equal(t, 5, handler.last.Volume)
+}
+
+func Test_ConsumeClaim_SendsPoisonMessageToDeadLetterQueue(t *testing.T) {
+	tests := map[string]string{
+		"not json":          `{`,
+		"invalid basket id": `{"basketId":"not-a-uuid","address":{"street":"Тверская"},"Volume":5}`,
+		"invalid command":   `{"basketId":"` + uuid.NewString() + `","Volume":5}`,
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := &fakeHandler{}
+			dlq := &fakeDeadLetterQueue{}
+			consumer, _ := newTestConsumer(handler, dlq)
+			session := &fakeSession{ctx: context.Background()}
+			message := &sarama.ConsumerMessage{Topic: "basket.confirmed", Value: []byte(value)}
+
+			err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+			assert.NoError(t, err)
+			assert.Equal(t, 0, handler.calls)
+			assert.Len(t, dlq.letters, 1)
+			assert.Equal(t, message, dlq.letters[0].message)
+			assert.Equal(t, 0, dlq.letters[0].attempts)
+			assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+		})
+	}
+}
+
+func Test_ConsumeClaim_RejectsMalformedBasketIdAndKeepsConsuming(t *testing.T) {
+	handler := &fakeHandler{}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, _ := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	malformed := &sarama.ConsumerMessage{
+		Topic: "basket.confirmed", Offset: 1,
+		Value: []byte(`{"basketId":"42","address":{"street":"Тверская"},"Volume":5}`),
+	}
+	next := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(malformed, next))
+
+	assert.NoError(t, err)
+	assert.Len(t, dlq.letters, 1)
+	assert.Equal(t, malformed, dlq.letters[0].message)
+	assert.ErrorIs(t, dlq.letters[0].cause, errs.ErrValueIsInvalid)
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, []*sarama.ConsumerMessage{malformed, next}, session.marked)
+}
+
+func Test_ConsumeClaim_RejectsMessageWhenHandlerPanics(t *testing.T) {
+	dlq := &fakeDeadLetterQueue{}
+	consumer, _ := newTestConsumer(panickingHandler{}, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	message := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Len(t, dlq.letters, 1)
+	assert.Equal(t, 0, dlq.letters[0].attempts)
+	assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+}
+
+type panickingHandler struct{}
+
+func (panickingHandler) Handle(_ context.Context, _ *commands.CreateOrderCommand) error {
+	panic("unexpected nil")
+}
+
+func Test_ConsumeClaim_TreatsExistingOrderAsHandled(t *testing.T) {
+	handler := &fakeHandler{errs: []error{commands.ErrOrderAlreadyExists}}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, pauses := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	message := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, handler.calls)
+	assert.Empty(t, *pauses)
+	assert.Empty(t, dlq.letters)
+	assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+}
+
+func Test_ConsumeClaim_RetriesTransientErrors(t *testing.T) {
+	outage := errors.New("geo service unavailable")
+	handler := &fakeHandler{errs: []error{outage, outage}}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, pauses := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	message := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, handler.calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *pauses)
+	assert.Empty(t, dlq.letters)
+	assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+}
+
+func Test_ConsumeClaim_SendsToDeadLetterQueueWhenRetriesExhausted(t *testing.T) {
+	outage := errors.New("database unavailable")
+	handler := &fakeHandler{errs: []error{outage, outage, outage}}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, pauses := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+	message := validMessage()
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(message))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, handler.calls)
+	assert.Len(t, *pauses, 2)
+	assert.Len(t, dlq.letters, 1)
+	assert.ErrorIs(t, dlq.letters[0].cause, outage)
+	assert.Equal(t, 3, dlq.letters[0].attempts)
+	assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+}
+
+func Test_ConsumeClaim_DoesNotRetryPermanentErrors(t *testing.T) {
+	handler := &fakeHandler{errs: []error{errs.NewValueIsInvalidError("location")}}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, pauses := newTestConsumer(handler, dlq)
+	session := &fakeSession{ctx: context.Background()}
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(validMessage()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, handler.calls)
+	assert.Empty(t, *pauses)
+	assert.Len(t, dlq.letters, 1)
+	assert.Equal(t, 1, dlq.letters[0].attempts)
+}
+
+func Test_ConsumeClaim_LeavesMessageUnmarkedWhenDeadLetterQueueFails(t *testing.T) {
+	dlqErr := errors.New("broker unavailable")
+	handler := &fakeHandler{}
+	consumer, _ := newTestConsumer(handler, &fakeDeadLetterQueue{err: dlqErr})
+	session := &fakeSession{ctx: context.Background()}
+	poison := &sarama.ConsumerMessage{Topic: "basket.confirmed", Value: []byte(`{`)}
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(poison, validMessage()))
+
+	assert.ErrorIs(t, err, dlqErr)
+	assert.Empty(t, session.marked)
+	assert.Equal(t, 0, handler.calls)
+}
+
+func Test_ConsumeClaim_StopsRetryingWhenSessionIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	handler := &fakeHandler{errs: []error{errors.New("timeout")}}
+	dlq := &fakeDeadLetterQueue{}
+	consumer, _ := newTestConsumer(handler, dlq)
+	consumer.sleep = sleep
+	session := &fakeSession{ctx: ctx}
+
+	err := consumer.ConsumeClaim(session, newFakeClaim(validMessage()))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, handler.calls)
+	assert.Empty(t, dlq.letters)
+	assert.Empty(t, session.marked)
+}
+
+func Test_RetryPolicy_BackoffDoublesUpToMax(t *testing.T) {
+	policy, err := NewRetryPolicy(10, time.Second, 5*time.Second)
+	assert.NoError(t, err)
+
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4))
+	assert.Equal(t, 5*time.Second, policy.Backoff(10))
+}
+
+func Test_DeadLetterMessage_KeepsPayloadAndAddsErrorHeaders(t *testing.T) {
+	failedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	message := &sarama.ConsumerMessage{
+		Topic: "basket.confirmed", Partition: 2, Offset: 7,
+		Key: []byte("key"), Value: []byte("payload"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("trace-id"), Value: []byte("abc")}},
+	}
+
+	dead := newDeadLetterMessage("basket.confirmed.dlq", message, errors.New("boom"), 3, failedAt)
+
+	assert.Equal(t, "basket.confirmed.dlq", dead.Topic)
+	assert.Equal(t, sarama.ByteEncoder("key"), dead.Key)
+	assert.Equal(t, sarama.ByteEncoder("payload"), dead.Value)
+	assert.Equal(t, map[string]string{
+		"trace-id":              "abc",
+		HeaderOriginalTopic:     "basket.confirmed",
+		HeaderOriginalPartition: "2",
+		HeaderOriginalOffset:    "7",
+		HeaderError:             "boom",
+		HeaderAttempts:          "3",
+		HeaderFailedAt:          "2024-05-01T10:00:00Z",
+	}, headerMap(dead.Headers))
+}
+
+func Test_ReplayMessage_RestoresOriginalMessage(t *testing.T) {
+	dead := newDeadLetterMessage("basket.confirmed.dlq", &sarama.ConsumerMessage{
+		Topic: "basket.confirmed.v2", Value: []byte("payload"),
+		Headers: []*sarama.RecordHeader{{Key: []byte("trace-id"), Value: []byte("abc")}},
+	}, errors.New("boom"), 1, time.Now())
+	headers := make([]*sarama.RecordHeader, 0, len(dead.Headers))
+	for i := range dead.Headers {
+		headers = append(headers, &dead.Headers[i])
+	}
+
+	replay := newReplayMessage(&sarama.ConsumerMessage{Value: []byte("payload"), Headers: headers}, "basket.confirmed")
+
+	assert.Equal(t, "basket.confirmed.v2", replay.Topic)
+	assert.Equal(t, sarama.ByteEncoder("payload"), replay.Value)
+	assert.Nil(t, replay.Key)
+	assert.Equal(t, map[string]string{"trace-id": "abc"}, headerMap(replay.Headers))
+}
+
+func Test_ReplayMessage_FallsBackToDefaultTopic(t *testing.T) {
+	replay := newReplayMessage(&sarama.ConsumerMessage{Value: []byte("payload")}, "basket.confirmed")
+
+	assert.Equal(t, "basket.confirmed", replay.Topic)
+}
+
+func headerMap(headers []sarama.RecordHeader) map[string]string {
+	result := make(map[string]string, len(headers))
+	for _, header := range headers {
+		result[string(header.Key)] = string(header.Value)
+	}
+	return result
+}
+
+func Test_DeliveryPeriodToTime_UsesDeliveryTimezone(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	utc := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := map[string]struct {
+		now      time.Time
+		timezone *time.Location
+		from, to int32
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		"utc slot later today": {
+			now: utc(1, 8, 0), timezone: time.UTC, from: 10, to: 12,
+			wantFrom: utc(1, 10, 0), wantTo: utc(1, 12, 0),
+		},
+		"local slot later today": {
+			now: utc(1, 5, 0), timezone: moscow, from: 10, to: 12,
+			wantFrom: utc(1, 7, 0), wantTo: utc(1, 9, 0),
+		},
+		"in utc still yesterday, locally already the next day": {
+			now: utc(1, 22, 30), timezone: moscow, from: 10, to: 12,
+			wantFrom: utc(2, 7, 0), wantTo: utc(2, 9, 0),
+		},
+		"local night slot starts before utc midnight": {
+			now: utc(1, 21, 30), timezone: moscow, from: 0, to: 2,
+			wantFrom: utc(1, 21, 0), wantTo: utc(1, 23, 0),
+		},
+		"local slot is over, the next one is tomorrow": {
+			now: utc(1, 20, 0), timezone: moscow, from: 18, to: 22,
+			wantFrom: utc(2, 15, 0), wantTo: utc(2, 19, 0),
+		},
+		"slot until local midnight": {
+			now: utc(31, 20, 0), timezone: moscow, from: 20, to: 24,
+			wantFrom: utc(31, 17, 0), wantTo: utc(31, 21, 0),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, to := deliveryPeriodToTime(&basketconfirmedpb.DeliveryPeriod{From: test.from, To: test.to},
+				test.now, test.timezone)
+
+			assert.Equal(t, test.wantFrom, from)
+			assert.Equal(t, test.wantTo, to)
+		})
+	}
+}
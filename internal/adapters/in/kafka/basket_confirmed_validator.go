@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"strings"
+)
+
+// validateBasketConfirmed проверяет событие до того, как из него собирается команда. Возвращает все
+// найденные нарушения сразу, каждое - типизированной ошибкой из errs
+func validateBasketConfirmed(event *basketconfirmedpb.BasketConfirmedIntegrationEvent) error {
+	var violations []error
+
+	if err := validateUUID("basketId", event.GetBasketId()); err != nil {
+		violations = append(violations, err)
+	}
+	if strings.TrimSpace(event.GetAddress().GetStreet()) == "" {
+		violations = append(violations, errs.NewValueIsRequiredError("address.street"))
+	}
+	if event.GetVolume() <= 0 {
+		violations = append(violations, errs.NewValueIsOutOfRangeError("volume", event.GetVolume(), 1, math.MaxInt32))
+	}
+	for i, item := range event.GetItems() {
+		if err := validateUUID(fmt.Sprintf("items[%d].id", i), item.GetId()); err != nil {
+			violations = append(violations, err)
+		}
+		if err := validateUUID(fmt.Sprintf("items[%d].goodId", i), item.GetGoodId()); err != nil {
+			violations = append(violations, err)
+		}
+		if item.GetQuantity() <= 0 {
+			violations = append(violations, errs.NewValueIsOutOfRangeError(
+				fmt.Sprintf("items[%d].quantity", i), item.GetQuantity(), 1, math.MaxInt32))
+		}
+	}
+
+	return errors.Join(violations...)
+}
+
+func validateUUID(paramName string, value string) error {
+	if value == "" {
+		return errs.NewValueIsRequiredError(paramName)
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return errs.NewValueIsInvalidErrorWithCause(paramName, err)
+	}
+	if id == uuid.Nil {
+		return errs.NewValueIsRequiredError(paramName)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func validEvent() *basketconfirmedpb.BasketConfirmedIntegrationEvent {
+	return &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: uuid.NewString(),
+		Address:  &basketconfirmedpb.Address{Street: "Тверская"},
+		Items: []*basketconfirmedpb.Item{
+			{Id: uuid.NewString(), GoodId: uuid.NewString(), Title: "Кофе", Quantity: 1},
+		},
+		Volume: 5,
+	}
+}
+
+func Test_ValidateBasketConfirmed_AcceptsValidEvent(t *testing.T) {
+	assert.NoError(t, validateBasketConfirmed(validEvent()))
+}
+
+func Test_ValidateBasketConfirmed_ReturnsTypedErrors(t *testing.T) {
+	tests := map[string]struct {
+		change   func(event *basketconfirmedpb.BasketConfirmedIntegrationEvent)
+		expected error
+	}{
+		"malformed basketId": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.BasketId = "not-a-uuid" },
+			expected: errs.ErrValueIsInvalid,
+		},
+		"empty basketId": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.BasketId = "" },
+			expected: errs.ErrValueIsRequired,
+		},
+		"nil basketId": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.BasketId = uuid.Nil.String() },
+			expected: errs.ErrValueIsRequired,
+		},
+		"blank street": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Address.Street = "  " },
+			expected: errs.ErrValueIsRequired,
+		},
+		"no address": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Address = nil },
+			expected: errs.ErrValueIsRequired,
+		},
+		"zero volume": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Volume = 0 },
+			expected: errs.ErrValueIsOutOfRange,
+		},
+		"negative volume": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Volume = -3 },
+			expected: errs.ErrValueIsOutOfRange,
+		},
+		"malformed item id": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Items[0].Id = "42" },
+			expected: errs.ErrValueIsInvalid,
+		},
+		"malformed item goodId": {
+			change:   func(e *basketconfirmedpb.BasketConfirmedIntegrationEvent) { e.Items[0].GoodId = "42" },
+			expected: errs.ErrValueIsInvalid,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			event := validEvent()
+			tt.change(event)
+
+			err := validateBasketConfirmed(event)
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func Test_ValidateBasketConfirmed_ReportsAllViolations(t *testing.T) {
+	event := &basketconfirmedpb.BasketConfirmedIntegrationEvent{BasketId: "bad"}
+
+	err := validateBasketConfirmed(event)
+
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
@@ -0,0 +1,119 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/pkg/errs"
+	"fmt"
+	"github.com/IBM/sarama"
+	"strconv"
+	"time"
+)
+
+// Заголовки, с которыми сообщение попадает в DLQ. Тело и ключ остаются исходными
+const (
+	HeaderOriginalTopic     = "dlq-original-topic"
+	HeaderOriginalPartition = "dlq-original-partition"
+	HeaderOriginalOffset    = "dlq-original-offset"
+	HeaderError             = "dlq-error"
+	HeaderAttempts          = "dlq-attempts"
+	HeaderFailedAt          = "dlq-failed-at"
+)
+
+// DeadLetterQueue принимает сообщения, которые не удалось обработать
+type DeadLetterQueue interface {
+	Send(ctx context.Context, message *sarama.ConsumerMessage, cause error, attempts int) error
+	Close() error
+}
+
+var _ DeadLetterQueue = &deadLetterQueue{}
+
+type deadLetterQueue struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func NewDeadLetterQueue(brokers []string, topic string) (DeadLetterQueue, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+	if topic == "" {
+		return nil, errs.NewValueIsRequiredError("topic")
+	}
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V3_4_0_0
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create sync producer: %w", err)
+	}
+
+	return &deadLetterQueue{
+		topic:    topic,
+		producer: producer,
+	}, nil
+}
+
+func (q *deadLetterQueue) Send(ctx context.Context, message *sarama.ConsumerMessage, cause error, attempts int) error {
+	msg := newDeadLetterMessage(q.topic, message, cause, attempts, time.Now().UTC())
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, _, err := q.producer.SendMessage(msg)
+		resultCh <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-resultCh:
+		return err
+	}
+}
+
+func (q *deadLetterQueue) Close() error {
+	return q.producer.Close()
+}
+
+func newDeadLetterMessage(topic string, message *sarama.ConsumerMessage, cause error, attempts int,
+	failedAt time.Time) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+6)
+	for _, header := range message.Headers {
+		if header != nil && !isDeadLetterHeader(string(header.Key)) {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		stringHeader(HeaderOriginalTopic, message.Topic),
+		stringHeader(HeaderOriginalPartition, strconv.Itoa(int(message.Partition))),
+		stringHeader(HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10)),
+		stringHeader(HeaderError, cause.Error()),
+		stringHeader(HeaderAttempts, strconv.Itoa(attempts)),
+		stringHeader(HeaderFailedAt, failedAt.Format(time.RFC3339)),
+	)
+
+	msg := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		msg.Key = sarama.ByteEncoder(message.Key)
+	}
+	return msg
+}
+
+func isDeadLetterHeader(key string) bool {
+	switch key {
+	case HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset, HeaderError, HeaderAttempts, HeaderFailedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+func stringHeader(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
@@ -0,0 +1,178 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"fmt"
+	"github.com/IBM/sarama"
+	"sync"
+)
+
+var _ ports.DeadLetterReplayer = &deadLetterReplayer{}
+
+// deadLetterReplayer вычитывает DLQ до конца и отправляет сообщения обратно в исходный топик.
+// Прочитанное место в DLQ хранится в отдельной группе потребителей, поэтому повторный вызов
+// переотправит только новые сообщения. Дубли заказов отсекает inbox
+type deadLetterReplayer struct {
+	brokers     []string
+	group       string
+	topic       string
+	targetTopic string
+
+	mu sync.Mutex
+}
+
+func NewDeadLetterReplayer(brokers []string, group string, topic string,
+	targetTopic string) (ports.DeadLetterReplayer, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+	if group == "" {
+		return nil, errs.NewValueIsRequiredError("group")
+	}
+	if topic == "" {
+		return nil, errs.NewValueIsRequiredError("topic")
+	}
+	if targetTopic == "" {
+		return nil, errs.NewValueIsRequiredError("targetTopic")
+	}
+
+	return &deadLetterReplayer{
+		brokers:     brokers,
+		group:       group,
+		topic:       topic,
+		targetTopic: targetTopic,
+	}, nil
+}
+
+func (r *deadLetterReplayer) Replay(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V3_4_0_0
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+	saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	saramaCfg.Consumer.Offsets.AutoCommit.Enable = false
+
+	client, err := sarama.NewClient(r.brokers, saramaCfg)
+	if err != nil {
+		return 0, fmt.Errorf("create client: %w", err)
+	}
+	defer client.Close()
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return 0, fmt.Errorf("create sync producer: %w", err)
+	}
+	defer producer.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return 0, fmt.Errorf("create consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	offsetManager, err := sarama.NewOffsetManagerFromClient(r.group, client)
+	if err != nil {
+		return 0, fmt.Errorf("create offset manager: %w", err)
+	}
+	defer offsetManager.Close()
+
+	partitions, err := client.Partitions(r.topic)
+	if err != nil {
+		return 0, fmt.Errorf("get partitions: %w", err)
+	}
+
+	replayed := 0
+	for _, partition := range partitions {
+		count, err := r.replayPartition(ctx, client, consumer, offsetManager, producer, partition)
+		replayed += count
+		if err != nil {
+			offsetManager.Commit()
+			return replayed, err
+		}
+	}
+	offsetManager.Commit()
+	return replayed, nil
+}
+
+func (r *deadLetterReplayer) replayPartition(ctx context.Context, client sarama.Client, consumer sarama.Consumer,
+	offsetManager sarama.OffsetManager, producer sarama.SyncProducer, partition int32) (int, error) {
+	// Читаем только то, что успело попасть в DLQ к началу вызова
+	highWaterMark, err := client.GetOffset(r.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, fmt.Errorf("get high water mark: %w", err)
+	}
+
+	partitionOffsetManager, err := offsetManager.ManagePartition(r.topic, partition)
+	if err != nil {
+		return 0, fmt.Errorf("manage partition: %w", err)
+	}
+	defer partitionOffsetManager.Close()
+
+	next, _ := partitionOffsetManager.NextOffset()
+	if next < 0 {
+		if next, err = client.GetOffset(r.topic, partition, sarama.OffsetOldest); err != nil {
+			return 0, fmt.Errorf("get oldest offset: %w", err)
+		}
+	}
+	if next >= highWaterMark {
+		return 0, nil
+	}
+
+	partitionConsumer, err := consumer.ConsumePartition(r.topic, partition, next)
+	if err != nil {
+		return 0, fmt.Errorf("consume partition: %w", err)
+	}
+	defer partitionConsumer.Close()
+
+	replayed := 0
+	for next < highWaterMark {
+		select {
+		case <-ctx.Done():
+			return replayed, ctx.Err()
+		case err := <-partitionConsumer.Errors():
+			return replayed, err
+		case message := <-partitionConsumer.Messages():
+			if _, _, err := producer.SendMessage(newReplayMessage(message, r.targetTopic)); err != nil {
+				return replayed, fmt.Errorf("republish message: %w", err)
+			}
+			next = message.Offset + 1
+			partitionOffsetManager.MarkOffset(next, "")
+			replayed++
+		}
+	}
+	return replayed, nil
+}
+
+// newReplayMessage восстанавливает исходное сообщение из письма в DLQ: топик берется из заголовка,
+// служебные заголовки DLQ отбрасываются
+func newReplayMessage(message *sarama.ConsumerMessage, defaultTopic string) *sarama.ProducerMessage {
+	topic := defaultTopic
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers))
+	for _, header := range message.Headers {
+		if header == nil {
+			continue
+		}
+		key := string(header.Key)
+		if key == HeaderOriginalTopic && len(header.Value) > 0 {
+			topic = string(header.Value)
+		}
+		if !isDeadLetterHeader(key) {
+			headers = append(headers, *header)
+		}
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		msg.Key = sarama.ByteEncoder(message.Key)
+	}
+	return msg
+}
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"delivery/internal/pkg/backoff"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"time"
+)
+
+// RetryPolicy - сколько раз и с какими паузами повторять обработку сообщения при временной ошибке
+// (недоступен геосервис, БД). Пауза растет вдвое после каждой попытки, но не больше MaxBackoff
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+func NewRetryPolicy(maxAttempts int, initialBackoff, maxBackoff time.Duration) (RetryPolicy, error) {
+	if maxAttempts <= 0 {
+		return RetryPolicy{}, errs.NewValueIsRequiredError("maxAttempts")
+	}
+	if initialBackoff < 0 {
+		return RetryPolicy{}, errs.NewValueIsInvalidError("initialBackoff")
+	}
+	if maxBackoff < initialBackoff {
+		return RetryPolicy{}, errs.NewValueIsInvalidError("maxBackoff")
+	}
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+	}, nil
+}
+
+// Backoff - пауза после неудачной попытки с номером attempt, попытки считаются с 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	return backoff.Exponential(attempt, p.InitialBackoff, p.MaxBackoff)
+}
+
+// isPermanent - ошибка, которая не исчезнет при повторе: сообщение само по себе некорректно
+func isPermanent(err error) bool {
+	return errors.Is(err, errs.ErrValueIsRequired) ||
+		errors.Is(err, errs.ErrValueIsInvalid) ||
+		errors.Is(err, errs.ErrValueIsOutOfRange)
+}
+
+// sleep ждет d или отмены ctx - при ребалансировке или остановке сервиса ждать дальше незачем
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -18,12 +18,17 @@ type Client struct {
 	conn     *grpc.ClientConn
 	pbClient geopb.GeoClient
 	timeout  time.Duration
+	grid     kernel.Grid
 }
 
-func NewClient(host string) (*Client, error) {
+// NewClient создает клиент геосервиса. Клетки, которые он возвращает, лежат на карте grid
+func NewClient(host string, grid kernel.Grid) (*Client, error) {
 	if host == "" {
 		return nil, errs.NewValueIsRequiredError("host")
 	}
+	if grid.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("grid")
+	}
 
 	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -36,6 +41,7 @@ func NewClient(host string) (*Client, error) {
 		conn:     conn,
 		pbClient: pbClient,
 		timeout:  5 * time.Second,
+		grid:     grid,
 	}, nil
 }
 
@@ -43,7 +49,7 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-func (c *Client) GetGeolocation(ctx context.Context, street string) (kernel.Location, error) {
+func (c *Client) GetGeolocation(ctx context.Context, street string) (kernel.Location, kernel.GeoCoordinate, error) {
 	// Формируем запрос
 	req := &geopb.GetGeolocationRequest{
 		Street: street,
@@ -54,13 +60,22 @@ func (c *Client) GetGeolocation(ctx context.Context, street string) (kernel.Loca
 	defer cancel()
 	resp, err := c.pbClient.GetGeolocation(ctx, req)
 	if err != nil {
-		return kernel.Location{}, err
+		return kernel.Location{}, kernel.GeoCoordinate{}, err
 	}
 
 	// Создаем и возвращаем VO Geo
-	location, err := kernel.NewLocation(int(resp.Location.X), int(resp.Location.Y))
+	location, err := c.grid.NewLocation(int(resp.Location.X), int(resp.Location.Y))
 	if err != nil {
-		return kernel.Location{}, err
+		return kernel.Location{}, kernel.GeoCoordinate{}, err
+	}
+
+	// Координаты отдает не каждая версия геосервиса
+	var coordinate kernel.GeoCoordinate
+	if resp.GetCoordinate() != nil {
+		coordinate, err = kernel.NewGeoCoordinate(resp.GetCoordinate().GetLatitude(), resp.GetCoordinate().GetLongitude())
+		if err != nil {
+			return kernel.Location{}, kernel.GeoCoordinate{}, err
+		}
 	}
-	return location, nil
+	return location, coordinate, nil
 }
@@ -5,25 +5,33 @@ import (
 	"delivery/internal/core/domain/model/order"
 	"delivery/internal/core/ports"
 	"delivery/internal/generated/queues/orderstatuschangedpb"
+	"delivery/internal/pkg/codec"
 	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
-	"encoding/json"
 	"fmt"
 	"github.com/IBM/sarama"
+	"github.com/google/uuid"
 )
 
 type orderProducer struct {
-	topic    string
-	producer sarama.SyncProducer
+	topic       string
+	contentType string
+	producer    sarama.SyncProducer
 }
 
-func NewOrderProducer(brokers []string, topic string) (ports.OrderProducer, error) {
+// NewOrderProducer создает продюсер, который пишет события в формате contentType
+// (codec.JSON или codec.Protobuf, пустая строка - JSON)
+func NewOrderProducer(brokers []string, topic string, contentType string) (ports.OrderProducer, error) {
 	if len(brokers) == 0 {
 		return nil, errs.NewValueIsRequiredError("brokers")
 	}
 	if topic == "" {
 		return nil, errs.NewValueIsRequiredError("topic")
 	}
+	contentType, err := codec.Parse(contentType)
+	if err != nil {
+		return nil, err
+	}
 
 	saramaCfg := sarama.NewConfig()
 	saramaCfg.Version = sarama.V3_4_0_0
@@ -35,31 +43,47 @@ func NewOrderProducer(brokers []string, topic string) (ports.OrderProducer, erro
 	}
 
 	return &orderProducer{
-		topic:    topic,
-		producer: producer,
+		topic:       topic,
+		contentType: contentType,
+		producer:    producer,
 	}, nil
 }
 
 func (p *orderProducer) Publish(ctx context.Context, domainEvent ddd.DomainEvent) error {
-	completedDomainEvent, ok := domainEvent.(*order.CompletedDomainEvent)
-	if !ok {
+	var (
+		orderID     uuid.UUID
+		orderStatus string
+	)
+	switch event := domainEvent.(type) {
+	case *order.CreatedDomainEvent:
+		orderID, orderStatus = event.OrderID, event.OrderStatus
+	case *order.AssignedDomainEvent:
+		orderID, orderStatus = event.OrderID, event.OrderStatus
+	case *order.CompletedDomainEvent:
+		orderID, orderStatus = event.OrderID, event.OrderStatus
+	case *order.CancelledDomainEvent:
+		orderID, orderStatus = event.OrderID, event.OrderStatus
+	default:
 		return fmt.Errorf("unexpected domain event type: %T", domainEvent)
 	}
 
-	integrationEvent, err := p.mapDomainEventToIntegrationEvent(completedDomainEvent)
+	integrationEvent, err := p.mapDomainEventToIntegrationEvent(orderID, orderStatus)
 	if err != nil {
 		return fmt.Errorf("map event: %w", err)
 	}
 
-	bytes, err := json.Marshal(integrationEvent)
+	bytes, err := codec.Marshal(p.contentType, integrationEvent)
 	if err != nil {
 		return fmt.Errorf("marshal event: %w", err)
 	}
 
 	msg := &sarama.ProducerMessage{
 		Topic: p.topic,
-		Key:   sarama.StringEncoder(completedDomainEvent.OrderID.String()),
+		Key:   sarama.StringEncoder(orderID.String()),
 		Value: sarama.ByteEncoder(bytes),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(codec.Header), Value: []byte(p.contentType)},
+		},
 	}
 
 	resultCh := make(chan error, 1)
@@ -81,14 +105,14 @@ func (p *orderProducer) Close() error {
 	return p.producer.Close()
 }
 
-func (p *orderProducer) mapDomainEventToIntegrationEvent(completedDomainEvent *order.CompletedDomainEvent) (*orderstatuschangedpb.OrderStatusChangedIntegrationEvent, error) {
-	status, ok := orderstatuschangedpb.OrderStatus_value[completedDomainEvent.OrderStatus]
+func (p *orderProducer) mapDomainEventToIntegrationEvent(orderID uuid.UUID, orderStatus string) (*orderstatuschangedpb.OrderStatusChangedIntegrationEvent, error) {
+	status, ok := orderstatuschangedpb.OrderStatus_value[orderStatus]
 	if !ok {
 		return nil, errs.NewValueIsInvalidError("OrderStatus")
 	}
 
 	integrationEvent := orderstatuschangedpb.OrderStatusChangedIntegrationEvent{
-		OrderId:     completedDomainEvent.OrderID.String(),
+		OrderId:     orderID.String(),
 		OrderStatus: orderstatuschangedpb.OrderStatus(status),
 	}
 	return &integrationEvent, nil
@@ -8,21 +8,47 @@ type CourierDTO struct {
 	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
 	Name          string
 	Speed         int
+	Transport     TransportDTO       `gorm:"embedded;embeddedPrefix:transport_"`
+	Status        string             `gorm:"default:'OnShift'"` // курьеры, созданные до появления смен, остаются на смене
 	StoragePlaces []*StoragePlaceDTO `gorm:"foreignKey:CourierID;constraint:OnDelete:CASCADE;"`
 	Location      LocationDTO        `gorm:"embedded;embeddedPrefix:location_"`
+	Coordinate    CoordinateDTO      `gorm:"embedded;embeddedPrefix:coordinate_"`
 }
 
 type StoragePlaceDTO struct {
-	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
-	OrderID     *uuid.UUID `gorm:"type:uuid;"`
-	Name        string
-	TotalVolume int
-	CourierID   uuid.UUID `gorm:"type:uuid;index"`
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name         string
+	TotalVolume  int
+	CourierID    uuid.UUID         `gorm:"type:uuid;index"`
+	StoredOrders []*StoredOrderDTO `gorm:"foreignKey:StoragePlaceID;constraint:OnDelete:CASCADE;"`
 }
 
+type StoredOrderDTO struct {
+	OrderID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Volume         int
+	StoragePlaceID uuid.UUID `gorm:"type:uuid;index"`
+}
+
+// TransportDTO - профиль транспорта курьера, скорость по сетке хранится в колонке speed курьера.
+// Курьеры, созданные до появления транспорта, получают профиль без ограничений по объему,
+// а без скорости по координатам (0) - скорость стандартного профиля
+type TransportDTO struct {
+	Name     string `gorm:"default:'custom'"`
+	GeoSpeed int    `gorm:"default:0"`
+	Capacity int    `gorm:"default:1000"`
+}
+
+// LocationDTO - клетка и зона ее карты. Пустая зона - записи, сохраненные до появления зон, они лежат на основной карте
 type LocationDTO struct {
-	X int
-	Y int
+	X    int
+	Y    int
+	Zone string `gorm:"default:''"`
+}
+
+// CoordinateDTO - географические координаты, NULL - не заданы
+type CoordinateDTO struct {
+	Latitude  *float64
+	Longitude *float64
 }
 
 func (CourierDTO) TableName() string {
@@ -32,3 +58,7 @@ func (CourierDTO) TableName() string {
 func (StoragePlaceDTO) TableName() string {
 	return "storage_places"
 }
+
+func (StoredOrderDTO) TableName() string {
+	return "stored_orders"
+}
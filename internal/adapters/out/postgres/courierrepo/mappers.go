@@ -10,33 +10,70 @@ func DomainToDTO(aggregate *courier.Courier) CourierDTO {
 	courierDTO.ID = aggregate.ID()
 	courierDTO.Name = aggregate.Name()
 	courierDTO.Speed = aggregate.Speed()
+	courierDTO.Transport = TransportDTO{
+		Name:     aggregate.Transport().Name(),
+		GeoSpeed: aggregate.Transport().GeoSpeed(),
+		Capacity: aggregate.Transport().Capacity(),
+	}
+	courierDTO.Status = aggregate.Status().String()
 	courierDTO.StoragePlaces = make([]*StoragePlaceDTO, 0)
 	for _, storagePlace := range aggregate.StoragePlaces() {
 		storagePlaceDTO := &StoragePlaceDTO{
-			ID:          storagePlace.ID(),
-			OrderID:     storagePlace.OrderID(),
-			Name:        storagePlace.Name(),
-			TotalVolume: storagePlace.TotalVolume(),
-			CourierID:   aggregate.ID(),
+			ID:           storagePlace.ID(),
+			Name:         storagePlace.Name(),
+			TotalVolume:  storagePlace.TotalVolume(),
+			CourierID:    aggregate.ID(),
+			StoredOrders: make([]*StoredOrderDTO, 0),
+		}
+		for _, storedOrder := range storagePlace.StoredOrders() {
+			storedOrderDTO := &StoredOrderDTO{
+				OrderID:        storedOrder.OrderID(),
+				Volume:         storedOrder.Volume(),
+				StoragePlaceID: storagePlace.ID(),
+			}
+			storagePlaceDTO.StoredOrders = append(storagePlaceDTO.StoredOrders, storedOrderDTO)
 		}
 		courierDTO.StoragePlaces = append(courierDTO.StoragePlaces, storagePlaceDTO)
 	}
 	courierDTO.Location = LocationDTO{
-		X: aggregate.Location().X(),
-		Y: aggregate.Location().Y(),
+		X:    aggregate.Location().X(),
+		Y:    aggregate.Location().Y(),
+		Zone: aggregate.Location().Grid().Zone(),
+	}
+	if coordinate := aggregate.Coordinate(); !coordinate.IsEmpty() {
+		latitude, longitude := coordinate.Latitude(), coordinate.Longitude()
+		courierDTO.Coordinate = CoordinateDTO{
+			Latitude:  &latitude,
+			Longitude: &longitude,
+		}
 	}
 	return courierDTO
 }
 
-func DtoToDomain(dto CourierDTO) *courier.Courier {
+// DtoToDomain восстанавливает агрегат, точка собирается на карте своей зоны
+func DtoToDomain(dto CourierDTO, zones kernel.Zones) (*courier.Courier, error) {
 	var aggregate *courier.Courier
 	var storagePlaces []*courier.StoragePlace
 	for _, dtoStoragePlace := range dto.StoragePlaces {
+		var storedOrders []courier.StoredOrder
+		for _, dtoStoredOrder := range dtoStoragePlace.StoredOrders {
+			storedOrders = append(storedOrders,
+				courier.RestoreStoredOrder(dtoStoredOrder.OrderID, dtoStoredOrder.Volume))
+		}
 		item := courier.RestoreStoragePlace(dtoStoragePlace.ID, dtoStoragePlace.Name,
-			dtoStoragePlace.TotalVolume, dtoStoragePlace.OrderID)
+			dtoStoragePlace.TotalVolume, storedOrders)
 		storagePlaces = append(storagePlaces, item)
 	}
-	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
-	aggregate = courier.RestoreCourier(dto.ID, dto.Name, dto.Speed, location, storagePlaces)
-	return aggregate
+	location, err := zones.NewLocation(dto.Location.Zone, dto.Location.X, dto.Location.Y)
+	if err != nil {
+		return nil, err
+	}
+	var coordinate kernel.GeoCoordinate
+	if dto.Coordinate.Latitude != nil && dto.Coordinate.Longitude != nil {
+		coordinate, _ = kernel.NewGeoCoordinate(*dto.Coordinate.Latitude, *dto.Coordinate.Longitude)
+	}
+	transport := courier.RestoreTransport(dto.Transport.Name, dto.Speed, dto.Transport.GeoSpeed, dto.Transport.Capacity)
+	aggregate = courier.RestoreCourier(dto.ID, dto.Name, transport, location, coordinate,
+		courier.Status(dto.Status), storagePlaces)
+	return aggregate, nil
 }
@@ -0,0 +1,32 @@
+package courierrepo
+
+import (
+	"gorm.io/gorm"
+)
+
+// legacyOrderIDColumn - колонка storage_places, в которой место хранения держало единственный заказ
+// до появления таблицы stored_orders
+const legacyOrderIDColumn = "order_id"
+
+// MigrateLegacyStoredOrders переносит заказы из storage_places.order_id в stored_orders и удаляет
+// старую колонку. Объем берется из заказа, а если заказа уже нет - место считается занятым целиком.
+// Выполняется после AutoMigrate таблиц курьеров и заказов, повторный запуск ничего не делает
+func MigrateLegacyStoredOrders(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&StoragePlaceDTO{}, legacyOrderIDColumn) {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO stored_orders (order_id, volume, storage_place_id)
+			SELECT sp.order_id, COALESCE(o.volume, sp.total_volume), sp.id
+			FROM storage_places sp
+			LEFT JOIN orders o ON o.id = sp.order_id
+			WHERE sp.order_id IS NOT NULL
+			ON CONFLICT (order_id) DO NOTHING`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&StoragePlaceDTO{}, legacyOrderIDColumn)
+	})
+}
@@ -3,26 +3,33 @@ package courierrepo
 import (
 	"context"
 	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 var _ ports.CourierRepository = &Repository{}
 
+const storedOrdersAssociation = "StoragePlaces.StoredOrders"
+
 type Repository struct {
 	tracker ports.UnitOfWork
+	zones   kernel.Zones
 }
 
-func NewRepository(tracker ports.UnitOfWork) (*Repository, error) {
+func NewRepository(tracker ports.UnitOfWork, zones kernel.Zones) (*Repository, error) {
 	if tracker == nil {
 		return nil, errs.NewValueIsRequiredError("tracker")
 	}
+	if zones.Primary().IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("zones")
+	}
 
 	return &Repository{
 		tracker: tracker,
+		zones:   zones,
 	}, nil
 }
 
@@ -61,7 +68,29 @@ func (r *Repository) Update(ctx context.Context, aggregate *courier.Courier) err
 	}
 	tx := r.tracker.Tx()
 
-	err := tx.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: true}).Save(&dto).Error
+	// Выданные заказы удаляем, оставшиеся сохраняем заново вместе с агрегатом
+	err := tx.WithContext(ctx).
+		Where("storage_place_id IN (?)", tx.Model(&StoragePlaceDTO{}).Select("id").Where("courier_id = ?", dto.ID)).
+		Delete(&StoredOrderDTO{}).Error
+	if err != nil {
+		return err
+	}
+
+	// Убранные из агрегата места хранения удаляем, иначе Save их просто не тронет
+	storagePlaceIDs := make([]uuid.UUID, 0, len(dto.StoragePlaces))
+	for _, storagePlace := range dto.StoragePlaces {
+		storagePlaceIDs = append(storagePlaceIDs, storagePlace.ID)
+	}
+	removedStoragePlaces := tx.WithContext(ctx).Where("courier_id = ?", dto.ID)
+	if len(storagePlaceIDs) > 0 {
+		removedStoragePlaces = removedStoragePlaces.Where("id NOT IN ?", storagePlaceIDs)
+	}
+	err = removedStoragePlaces.Delete(&StoragePlaceDTO{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: true}).Save(&dto).Error
 	if err != nil {
 		return err
 	}
@@ -79,14 +108,13 @@ func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*courier.Courier, e
 
 	tx := r.getTxOrDb()
 	result := tx.WithContext(ctx).
-		Preload(clause.Associations).
+		Preload(storedOrdersAssociation).
 		Find(&dto, ID)
 	if result.RowsAffected == 0 {
 		return nil, errs.NewObjectNotFoundError(ID.String(), nil)
 	}
 
-	aggregate := DtoToDomain(dto)
-	return aggregate, nil
+	return DtoToDomain(dto, r.zones)
 }
 
 func (r *Repository) GetAllFree(ctx context.Context) ([]*courier.Courier, error) {
@@ -94,11 +122,15 @@ func (r *Repository) GetAllFree(ctx context.Context) ([]*courier.Courier, error)
 
 	tx := r.getTxOrDb()
 	result := tx.WithContext(ctx).
-		Preload(clause.Associations).
+		Preload(storedOrdersAssociation).
+		Where("status = ?", courier.StatusOnShift.String()).
 		Where(`
-        NOT EXISTS (
+        EXISTS (
             SELECT 1 FROM storage_places sp
-            WHERE sp.courier_id = couriers.id AND sp.order_id IS NOT NULL
+            WHERE sp.courier_id = couriers.id AND sp.total_volume > (
+                SELECT COALESCE(SUM(so.volume), 0) FROM stored_orders so
+                WHERE so.storage_place_id = sp.id
+            )
         )`).Find(&dtos)
 	if result.Error != nil {
 		return nil, result.Error
@@ -109,7 +141,11 @@ func (r *Repository) GetAllFree(ctx context.Context) ([]*courier.Courier, error)
 
 	aggregates := make([]*courier.Courier, len(dtos))
 	for i, dto := range dtos {
-		aggregates[i] = DtoToDomain(dto)
+		aggregate, err := DtoToDomain(dto, r.zones)
+		if err != nil {
+			return nil, err
+		}
+		aggregates[i] = aggregate
 	}
 
 	return aggregates, nil
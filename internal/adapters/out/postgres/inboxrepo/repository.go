@@ -0,0 +1,74 @@
+package inboxrepo
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/inbox"
+	"delivery/internal/pkg/uow"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var _ ports.InboxRepository = &Repository{}
+
+type Repository struct {
+	tracker uow.Tracker
+}
+
+func NewRepository(tracker uow.Tracker) (*Repository, error) {
+	if tracker == nil {
+		return nil, errs.NewValueIsRequiredError("tracker")
+	}
+
+	return &Repository{
+		tracker: tracker,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, message *inbox.Message) error {
+	if message == nil {
+		return errs.NewValueIsRequiredError("message")
+	}
+	if message.ProcessedAtUtc.IsZero() {
+		message.ProcessedAtUtc = time.Now().UTC()
+	}
+
+	// Конкурентная вставка того же сообщения ждет на уникальном индексе, пока первая транзакция не завершится,
+	// и после ее коммита ничего не вставляет
+	result := r.getTxOrDb().WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(message)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return inbox.ErrAlreadyProcessed
+	}
+	return nil
+}
+
+func (r *Repository) Exists(ctx context.Context, message *inbox.Message) (bool, error) {
+	if message == nil {
+		return false, errs.NewValueIsRequiredError("message")
+	}
+
+	var count int64
+	err := r.getTxOrDb().WithContext(ctx).
+		Model(&inbox.Message{}).
+		Where(`(topic = ? AND "partition" = ? AND "offset" = ?) OR basket_id = ?`,
+			message.Topic, message.Partition, message.Offset, message.BasketID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) getTxOrDb() *gorm.DB {
+	if tx := r.tracker.Tx(); tx != nil {
+		return tx
+	}
+	return r.tracker.Db()
+}
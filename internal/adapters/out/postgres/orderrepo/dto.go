@@ -3,21 +3,81 @@ package orderrepo
 import (
 	"delivery/internal/core/domain/model/order"
 	"github.com/google/uuid"
+	"time"
 )
 
 type OrderDTO struct {
-	ID        uuid.UUID   `gorm:"type:uuid;primaryKey"`
-	CourierID *uuid.UUID  `gorm:"type:uuid;index"`
-	Location  LocationDTO `gorm:"embedded;embeddedPrefix:location_"`
-	Volume    int
-	Status    order.Status `gorm:"type:varchar(20)"`
+	ID           uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	CourierID    *uuid.UUID    `gorm:"type:uuid;index"`
+	Location     LocationDTO   `gorm:"embedded;embeddedPrefix:location_"`
+	Coordinate   CoordinateDTO `gorm:"embedded;embeddedPrefix:coordinate_"`
+	Volume       int
+	Status       order.Status `gorm:"type:varchar(20)"`
+	CancelReason string
+	CreatedAt    time.Time   `gorm:"not null;default:now();index"`
+	Address      *AddressDTO `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;"`
+	Items        []*ItemDTO  `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE;"`
+
+	DeliveryWindow DeliveryWindowDTO `gorm:"embedded;embeddedPrefix:delivery_window_"`
+	Late           bool
 }
 
+// LocationDTO - клетка и зона ее карты. Пустая зона - записи, сохраненные до появления зон, они лежат на основной карте
 type LocationDTO struct {
-	X int
-	Y int
+	X    int
+	Y    int
+	Zone string `gorm:"default:''"`
+}
+
+// CoordinateDTO - географические координаты, NULL - не заданы
+type CoordinateDTO struct {
+	Latitude  *float64
+	Longitude *float64
+}
+
+type AddressDTO struct {
+	OrderID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Country   string
+	City      string
+	Street    string
+	House     string
+	Apartment string
+}
+
+type ItemDTO struct {
+	OrderID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	GoodID   uuid.UUID `gorm:"type:uuid"`
+	Title    string
+	Price    float64
+	Quantity int
+}
+
+// StatusHistoryDTO - запись истории статусов заказа. Агрегат ее не читает, она нужна только для запросов
+type StatusHistoryDTO struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	OrderID   uuid.UUID    `gorm:"type:uuid;index"`
+	Status    order.Status `gorm:"type:varchar(20)"`
+	ChangedAt time.Time
+}
+
+type DeliveryWindowDTO struct {
+	From *time.Time
+	To   *time.Time `gorm:"index"`
 }
 
 func (OrderDTO) TableName() string {
 	return "orders"
 }
+
+func (AddressDTO) TableName() string {
+	return "order_addresses"
+}
+
+func (ItemDTO) TableName() string {
+	return "order_items"
+}
+
+func (StatusHistoryDTO) TableName() string {
+	return "order_status_history"
+}
@@ -10,17 +10,79 @@ func DomainToDTO(aggregate *order.Order) OrderDTO {
 	orderDTO.ID = aggregate.Id()
 	orderDTO.CourierID = aggregate.CourierId()
 	orderDTO.Location = LocationDTO{
-		X: aggregate.Location().X(),
-		Y: aggregate.Location().Y(),
+		X:    aggregate.Location().X(),
+		Y:    aggregate.Location().Y(),
+		Zone: aggregate.Location().Grid().Zone(),
+	}
+	if coordinate := aggregate.Coordinate(); !coordinate.IsEmpty() {
+		latitude, longitude := coordinate.Latitude(), coordinate.Longitude()
+		orderDTO.Coordinate = CoordinateDTO{
+			Latitude:  &latitude,
+			Longitude: &longitude,
+		}
 	}
 	orderDTO.Volume = aggregate.Volume()
 	orderDTO.Status = aggregate.Status()
+	orderDTO.CancelReason = aggregate.CancelReason()
+	orderDTO.CreatedAt = aggregate.CreatedAt()
+	if address := aggregate.Address(); !address.IsEmpty() {
+		orderDTO.Address = &AddressDTO{
+			OrderID:   aggregate.Id(),
+			Country:   address.Country(),
+			City:      address.City(),
+			Street:    address.Street(),
+			House:     address.House(),
+			Apartment: address.Apartment(),
+		}
+	}
+	orderDTO.Items = make([]*ItemDTO, 0)
+	for _, item := range aggregate.Items() {
+		orderDTO.Items = append(orderDTO.Items, &ItemDTO{
+			OrderID:  aggregate.Id(),
+			ID:       item.ID(),
+			GoodID:   item.GoodID(),
+			Title:    item.Title(),
+			Price:    item.Price(),
+			Quantity: item.Quantity(),
+		})
+	}
+	if window := aggregate.DeliveryWindow(); !window.IsEmpty() {
+		from, to := window.From(), window.To()
+		orderDTO.DeliveryWindow = DeliveryWindowDTO{
+			From: &from,
+			To:   &to,
+		}
+	}
+	orderDTO.Late = aggregate.IsLate()
 	return orderDTO
 }
 
-func DtoToDomain(dto OrderDTO) *order.Order {
+// DtoToDomain восстанавливает агрегат, точка собирается на карте своей зоны
+func DtoToDomain(dto OrderDTO, zones kernel.Zones) (*order.Order, error) {
 	var aggregate *order.Order
-	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
-	aggregate = order.RestoreOrder(dto.ID, dto.CourierID, location, dto.Volume, dto.Status)
-	return aggregate
+	location, err := zones.NewLocation(dto.Location.Zone, dto.Location.X, dto.Location.Y)
+	if err != nil {
+		return nil, err
+	}
+	var coordinate kernel.GeoCoordinate
+	if dto.Coordinate.Latitude != nil && dto.Coordinate.Longitude != nil {
+		coordinate, _ = kernel.NewGeoCoordinate(*dto.Coordinate.Latitude, *dto.Coordinate.Longitude)
+	}
+	var address kernel.Address
+	if dto.Address != nil {
+		address, _ = kernel.NewAddress(dto.Address.Country, dto.Address.City, dto.Address.Street,
+			dto.Address.House, dto.Address.Apartment)
+	}
+	var items []order.Item
+	for _, dtoItem := range dto.Items {
+		items = append(items,
+			order.RestoreItem(dtoItem.ID, dtoItem.GoodID, dtoItem.Title, dtoItem.Price, dtoItem.Quantity))
+	}
+	var deliveryWindow order.DeliveryWindow
+	if dto.DeliveryWindow.From != nil && dto.DeliveryWindow.To != nil {
+		deliveryWindow, _ = order.NewDeliveryWindow(*dto.DeliveryWindow.From, *dto.DeliveryWindow.To)
+	}
+	aggregate = order.RestoreOrder(dto.ID, dto.CourierID, location, coordinate, dto.Volume, dto.Status, dto.CancelReason,
+		dto.CreatedAt, address, items, deliveryWindow, dto.Late)
+	return aggregate, nil
 }
@@ -2,29 +2,35 @@ package orderrepo
 
 import (
 	"context"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/domain/model/order"
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
 	"delivery/internal/pkg/uow"
-	"errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"time"
 )
 
 var _ ports.OrderRepository = &Repository{}
 
 type Repository struct {
 	tracker uow.Tracker
+	zones   kernel.Zones
 }
 
-func NewRepository(tracker uow.Tracker) (*Repository, error) {
+func NewRepository(tracker uow.Tracker, zones kernel.Zones) (*Repository, error) {
 	if tracker == nil {
 		return nil, errs.NewValueIsRequiredError("tracker")
 	}
+	if zones.Primary().IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("zones")
+	}
 
 	return &Repository{
 		tracker: tracker,
+		zones:   zones,
 	}, nil
 }
 
@@ -46,6 +52,14 @@ func (r *Repository) Add(ctx context.Context, aggregate *order.Order) error {
 		return err
 	}
 
+	err = appendStatusHistory(ctx, tx, dto)
+	if err != nil {
+		if !isInTransaction {
+			_ = r.tracker.Rollback()
+		}
+		return err
+	}
+
 	if !isInTransaction {
 		if err := r.tracker.Commit(ctx); err != nil {
 			return err
@@ -72,6 +86,14 @@ func (r *Repository) Update(ctx context.Context, aggregate *order.Order) error {
 		return err
 	}
 
+	err = appendStatusHistory(ctx, tx, dto)
+	if err != nil {
+		if !isInTransaction {
+			_ = r.tracker.Rollback()
+		}
+		return err
+	}
+
 	if !isInTransaction {
 		if err := r.tracker.Commit(ctx); err != nil {
 			return err
@@ -91,27 +113,68 @@ func (r *Repository) Get(ctx context.Context, ID uuid.UUID) (*order.Order, error
 		return nil, nil
 	}
 
-	aggregate := DtoToDomain(dto)
-	return aggregate, nil
+	return DtoToDomain(dto, r.zones)
 }
 
-func (r *Repository) GetFirstInCreatedStatus(ctx context.Context) (*order.Order, error) {
-	dto := OrderDTO{}
+// GetFirstInCreatedStatus возвращает до limit созданных заказов: сначала те, чье окно доставки
+// закрывается раньше, затем заказы без окна
+func (r *Repository) GetFirstInCreatedStatus(ctx context.Context, limit int) ([]*order.Order, error) {
+	if limit <= 0 {
+		return nil, errs.NewValueIsRequiredError("limit")
+	}
 
+	var dtos []OrderDTO
 	tx := r.getTxOrDb()
 	result := tx.WithContext(ctx).
 		Preload(clause.Associations).
 		Where("status = ?", order.StatusCreated).
-		First(&dto)
+		Order("delivery_window_to ASC NULLS LAST").
+		Order("id").
+		Limit(limit).
+		Find(&dtos)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errs.NewObjectNotFoundError("Free courier", nil)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NewObjectNotFoundError("Created orders", nil)
+	}
+
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregate, err := DtoToDomain(dto, r.zones)
+		if err != nil {
+			return nil, err
 		}
+		aggregates[i] = aggregate
+	}
+	return aggregates, nil
+}
+
+func (r *Repository) GetAllInCreatedStatus(ctx context.Context) ([]*order.Order, error) {
+	var dtos []OrderDTO
+
+	tx := r.getTxOrDb()
+	result := tx.WithContext(ctx).
+		Preload(clause.Associations).
+		Where("status = ?", order.StatusCreated).
+		Find(&dtos)
+	if result.Error != nil {
 		return nil, result.Error
 	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NewObjectNotFoundError("Created orders", nil)
+	}
 
-	aggregate := DtoToDomain(dto)
-	return aggregate, nil
+	aggregates := make([]*order.Order, len(dtos))
+	for i, dto := range dtos {
+		aggregate, err := DtoToDomain(dto, r.zones)
+		if err != nil {
+			return nil, err
+		}
+		aggregates[i] = aggregate
+	}
+
+	return aggregates, nil
 }
 
 func (r *Repository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order, error) {
@@ -131,12 +194,41 @@ func (r *Repository) GetAllInAssignedStatus(ctx context.Context) ([]*order.Order
 
 	aggregates := make([]*order.Order, len(dtos))
 	for i, dto := range dtos {
-		aggregates[i] = DtoToDomain(dto)
+		aggregate, err := DtoToDomain(dto, r.zones)
+		if err != nil {
+			return nil, err
+		}
+		aggregates[i] = aggregate
 	}
 
 	return aggregates, nil
 }
 
+// appendStatusHistory дописывает статус заказа в историю, если он отличается от последнего записанного
+func appendStatusHistory(ctx context.Context, tx *gorm.DB, dto OrderDTO) error {
+	var lastStatus order.Status
+	err := tx.WithContext(ctx).
+		Model(&StatusHistoryDTO{}).
+		Select("status").
+		Where("order_id = ?", dto.ID).
+		Order("changed_at DESC").
+		Limit(1).
+		Scan(&lastStatus).Error
+	if err != nil {
+		return err
+	}
+	if lastStatus == dto.Status {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(&StatusHistoryDTO{
+		ID:        uuid.New(),
+		OrderID:   dto.ID,
+		Status:    dto.Status,
+		ChangedAt: time.Now().UTC(),
+	}).Error
+}
+
 func (r *Repository) getTxOrDb() *gorm.DB {
 	if tx := r.tracker.Tx(); tx != nil {
 		return tx
@@ -4,12 +4,32 @@ import (
 	"context"
 	"delivery/internal/pkg/errs"
 	"delivery/internal/pkg/outbox"
+	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
+// ErrUndeliverable означает, что сообщение не уйдет ни с какой попытки, например его не удается декодировать
+var ErrUndeliverable = errors.New("outbox message cannot be delivered")
+
+// PublishFunc отправляет одно сообщение Outbox, ошибка означает неудачную попытку.
+// Ошибка, обернутая в ErrUndeliverable, сразу откладывает сообщение без повторов
+type PublishFunc func(ctx context.Context, message *outbox.Message) error
+
+// BackoffFunc возвращает паузу перед следующей отправкой сообщения после attempts неудачных попыток
+type BackoffFunc func(attempts int) time.Duration
+
 type OutboxRepository interface {
-	Update(ctx context.Context, message *outbox.Message) error
-	GetNotPublishedMessages() ([]*outbox.Message, error)
+	// PublishBatch захватывает до batchSize неотправленных сообщений, чья очередь на отправку уже подошла,
+	// отправляет их через publish и в той же транзакции сохраняет результат. Из событий одного агрегата
+	// захватывается только самое раннее неотправленное, поэтому следующие ждут, пока оно не уйдет.
+	// После неудачи следующая попытка откладывается на backoff, а после maxAttempts попыток
+	// или ошибки ErrUndeliverable сообщение откладывается для ручного разбора вместе с причиной.
+	// Захваченные строки заблокированы до конца транзакции, а другие реплики их пропускают,
+	// поэтому каждое сообщение отправляет только одна из них. Возвращает количество успешно отправленных сообщений
+	PublishBatch(ctx context.Context, batchSize int, maxAttempts int, backoff BackoffFunc,
+		publish PublishFunc) (int, error)
 }
 
 var _ OutboxRepository = &repository{}
@@ -28,25 +48,71 @@ func NewRepository(db *gorm.DB) (OutboxRepository, error) {
 	}, nil
 }
 
-func (r *repository) Update(ctx context.Context, message *outbox.Message) error {
-	err := r.db.WithContext(ctx).Save(&message).Error
-	if err != nil {
-		return err
+func (r *repository) PublishBatch(ctx context.Context, batchSize int, maxAttempts int, backoff BackoffFunc,
+	publish PublishFunc) (int, error) {
+	if batchSize <= 0 {
+		return 0, errs.NewValueIsRequiredError("batchSize")
 	}
-	return nil
-}
-
-func (r *repository) GetNotPublishedMessages() ([]*outbox.Message, error) {
-	var events []*outbox.Message
-	result := r.db.
-		Order("occurred_at_utc ASC").
-		Limit(20).
-		Where("processed_at_utc IS NULL").Find(&events)
-	if result.Error != nil {
-		return nil, result.Error
+	if maxAttempts <= 0 {
+		return 0, errs.NewValueIsRequiredError("maxAttempts")
 	}
-	if result.RowsAffected == 0 {
-		return nil, nil
+	if backoff == nil {
+		return 0, errs.NewValueIsRequiredError("backoff")
+	}
+	if publish == nil {
+		return 0, errs.NewValueIsRequiredError("publish")
+	}
+
+	published := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var messages []*outbox.Message
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("processed_at_utc IS NULL AND parked_at_utc IS NULL").
+			Where("(next_attempt_at_utc IS NULL OR next_attempt_at_utc <= ?)", time.Now().UTC()).
+			// Пропускаем событие, если раньше него у того же агрегата есть неотправленное
+			Where(`(aggregate_id IS NULL OR NOT EXISTS (
+				SELECT 1 FROM outbox AS earlier
+				WHERE earlier.aggregate_id = outbox.aggregate_id
+					AND earlier.processed_at_utc IS NULL
+					AND earlier.parked_at_utc IS NULL
+					AND earlier.sequence_number < outbox.sequence_number))`).
+			Order("sequence_number ASC").
+			Limit(batchSize).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			message.Attempts++
+			if err := publish(ctx, message); err != nil {
+				lastError := err.Error()
+				message.LastError = &lastError
+				if errors.Is(err, ErrUndeliverable) || message.Attempts >= maxAttempts {
+					parkedAt := time.Now().UTC()
+					message.ParkedAtUtc = &parkedAt
+					message.NextAttemptAtUtc = nil
+				} else {
+					nextAttemptAt := time.Now().UTC().Add(backoff(message.Attempts))
+					message.NextAttemptAtUtc = &nextAttemptAt
+				}
+			} else {
+				now := time.Now().UTC()
+				message.ProcessedAtUtc = &now
+				message.LastError = nil
+				message.NextAttemptAtUtc = nil
+				published++
+			}
+
+			if err := tx.Save(message).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-	return events, nil
+	return published, nil
 }
@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"delivery/internal/adapters/out/postgres/courierrepo"
+	"delivery/internal/adapters/out/postgres/inboxrepo"
 	"delivery/internal/adapters/out/postgres/orderrepo"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
@@ -21,18 +23,22 @@ type UnitOfWork struct {
 	trackedAggregates []ddd.AggregateRoot
 	courierRepository ports.CourierRepository
 	orderRepository   ports.OrderRepository
+	inboxRepository   ports.InboxRepository
 }
 
+// Rollback откатывает транзакцию и забывает агрегаты, отслеженные в ней, чтобы их события
+// не попали в outbox следующей транзакции
 func (u *UnitOfWork) Rollback() error {
 	if u.tx != nil {
 		err := u.tx.Rollback().Error
-		u.tx = nil
+		u.clearTx()
 		return err
 	}
 	return nil
 }
 
-func NewUnitOfWork(db *gorm.DB) (ports.UnitOfWork, error) {
+// NewUnitOfWork собирает репозитории, которые восстанавливают точки на картах переданных зон
+func NewUnitOfWork(db *gorm.DB, zones kernel.Zones) (ports.UnitOfWork, error) {
 	if db == nil {
 		return nil, errs.NewValueIsRequiredError("db")
 	}
@@ -41,18 +47,24 @@ func NewUnitOfWork(db *gorm.DB) (ports.UnitOfWork, error) {
 		db: db,
 	}
 
-	courierRepo, err := courierrepo.NewRepository(uow)
+	courierRepo, err := courierrepo.NewRepository(uow, zones)
 	if err != nil {
 		return nil, err
 	}
 	uow.courierRepository = courierRepo
 
-	orderRepo, err := orderrepo.NewRepository(uow)
+	orderRepo, err := orderrepo.NewRepository(uow, zones)
 	if err != nil {
 		return nil, err
 	}
 	uow.orderRepository = orderRepo
 
+	inboxRepo, err := inboxrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.inboxRepository = inboxRepo
+
 	return uow, nil
 }
 
@@ -80,6 +92,10 @@ func (u *UnitOfWork) OrderRepository() ports.OrderRepository {
 	return u.orderRepository
 }
 
+func (u *UnitOfWork) InboxRepository() ports.InboxRepository {
+	return u.inboxRepository
+}
+
 func (u *UnitOfWork) Begin(ctx context.Context) {
 	u.tx = u.db.WithContext(ctx).Begin()
 }
@@ -4,15 +4,24 @@ import (
 	"context"
 	"delivery/internal/adapters/out/postgres/courierrepo"
 	"delivery/internal/adapters/out/postgres/orderrepo"
+	"delivery/internal/core/application/usecases/commands"
+	"delivery/internal/core/application/usecases/queries"
 	"delivery/internal/core/domain/model/courier"
 	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/domain/sevices"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/inbox"
+	"delivery/internal/pkg/outbox"
 	"delivery/internal/pkg/testcnts"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	postgresgorm "gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"sync"
 	"testing"
+	"time"
 )
 
 func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
@@ -31,8 +40,20 @@ func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
 	assert.NoError(t, err)
 	err = db.AutoMigrate(&courierrepo.StoragePlaceDTO{})
 	assert.NoError(t, err)
+	err = db.AutoMigrate(&courierrepo.StoredOrderDTO{})
+	assert.NoError(t, err)
 	err = db.AutoMigrate(&orderrepo.OrderDTO{})
 	assert.NoError(t, err)
+	err = db.AutoMigrate(&orderrepo.AddressDTO{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&orderrepo.ItemDTO{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&orderrepo.StatusHistoryDTO{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&outbox.Message{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&inbox.Message{})
+	assert.NoError(t, err)
 
 	err = db.AutoMigrate(&courierrepo.StoragePlaceDTO{})
 	assert.NoError(t, err)
@@ -46,18 +67,73 @@ func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
 	return ctx, db, nil
 }
 
+func defaultZones(t *testing.T) kernel.Zones {
+	zones, err := kernel.NewZones(kernel.DefaultGrid())
+	assert.NoError(t, err)
+	return zones
+}
+
+func Test_RepositoriesShouldRestoreLocationsOnTheirZoneGrid(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Сервис работает с картой северной зоны, записи без зоны остались от исторической карты
+	northGrid, err := kernel.NewGrid("north", 1, 1, 50, 30)
+	assert.NoError(t, err)
+	northGrid, err = northGrid.WithBlockedCells(kernel.Cell{X: 20, Y: 20})
+	assert.NoError(t, err)
+	zones, err := kernel.NewZones(northGrid, kernel.DefaultGrid())
+	assert.NoError(t, err)
+	uow, err := NewUnitOfWork(db, zones)
+	assert.NoError(t, err)
+
+	courierLocation, err := northGrid.NewLocation(40, 25)
+	assert.NoError(t, err)
+	courierAggregate, err := courier.NewCourier("Велосипедист", courier.Bicycle, courierLocation)
+	assert.NoError(t, err)
+	assert.NoError(t, uow.CourierRepository().Add(ctx, courierAggregate))
+	orderLocation, err := northGrid.NewLocation(45, 30)
+	assert.NoError(t, err)
+	orderAggregate, err := order.NewOrder(uuid.New(), orderLocation, 3)
+	assert.NoError(t, err)
+	assert.NoError(t, uow.OrderRepository().Add(ctx, orderAggregate))
+
+	// Точки читаются на той же карте, вместе с перекрытиями
+	courierFromDb, err := uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.True(t, courierFromDb.Location().Equals(courierLocation))
+	assert.True(t, courierFromDb.Location().Grid().IsBlocked(20, 20))
+	orderFromDb, err := uow.OrderRepository().Get(ctx, orderAggregate.Id())
+	assert.NoError(t, err)
+	assert.True(t, orderFromDb.Location().Equals(orderLocation))
+
+	// Запись без зоны относится к основной карте
+	assert.NoError(t, db.Model(&courierrepo.CourierDTO{}).
+		Where("id = ?", courierAggregate.ID()).Update("location_zone", "").Error)
+	courierFromDb, err = uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.True(t, courierFromDb.Location().Grid().Equals(northGrid))
+
+	// Зона, которой нет в конфигурации, не подменяется другой картой
+	assert.NoError(t, db.Model(&courierrepo.CourierDTO{}).
+		Where("id = ?", courierAggregate.ID()).Update("location_zone", "south").Error)
+	_, err = uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.ErrorIs(t, err, kernel.ErrUnknownZone)
+}
+
 func Test_CourierRepositoryShouldCanAddCourier(t *testing.T) {
 	// Инициализируем окружение
 	ctx, db, err := setupTest(t)
 	assert.NoError(t, err)
 
 	// Создаем UnitOfWork
-	uow, err := NewUnitOfWork(db)
+	uow, err := NewUnitOfWork(db, defaultZones(t))
 	assert.NoError(t, err)
 
 	// Вызываем Add
 	location := kernel.MaxLocation()
-	courierAggregate, err := courier.NewCourier("Велосипедист", 2, location)
+	courierAggregate, err := courier.NewCourier("Велосипедист", courier.Bicycle, location)
 	err = uow.CourierRepository().Add(ctx, courierAggregate)
 	assert.NoError(t, err)
 
@@ -69,6 +145,8 @@ func Test_CourierRepositoryShouldCanAddCourier(t *testing.T) {
 	// Проверяем эквивалентность
 	assert.Equal(t, courierAggregate.ID(), courierFromDb.ID)
 	assert.Equal(t, courierAggregate.Speed(), courierFromDb.Speed)
+	assert.Equal(t, courier.TransportBicycle, courierFromDb.Transport.Name)
+	assert.Equal(t, courier.Bicycle.Capacity(), courierFromDb.Transport.Capacity)
 }
 
 func Test_OrderRepositoryShouldCanAddOrder(t *testing.T) {
@@ -77,7 +155,7 @@ func Test_OrderRepositoryShouldCanAddOrder(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Создаем UnitOfWork
-	uow, err := NewUnitOfWork(db)
+	uow, err := NewUnitOfWork(db, defaultZones(t))
 	assert.NoError(t, err)
 
 	// Вызываем Add
@@ -98,3 +176,384 @@ func Test_OrderRepositoryShouldCanAddOrder(t *testing.T) {
 	assert.Equal(t, orderAggregate.Volume(), orderFromDb.Volume)
 	assert.Equal(t, orderAggregate.Status(), orderFromDb.Status)
 }
+
+func Test_OrderRepositoryShouldPersistAddressAndItems(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Создаем UnitOfWork
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Заказ с полным адресом и двумя товарами
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 10)
+	assert.NoError(t, err)
+	address, err := kernel.NewAddress("Россия", "Москва", "Тверская", "1", "10")
+	assert.NoError(t, err)
+	assert.NoError(t, orderAggregate.SetAddress(address))
+	firstItem, _ := order.NewItem(uuid.New(), uuid.New(), "Пицца", 500, 2)
+	secondItem, _ := order.NewItem(uuid.New(), uuid.New(), "Сок", 100, 1)
+	assert.NoError(t, orderAggregate.AddItem(firstItem))
+	assert.NoError(t, orderAggregate.AddItem(secondItem))
+	err = uow.OrderRepository().Add(ctx, orderAggregate)
+	assert.NoError(t, err)
+
+	// Считываем данные из БД
+	orderFromDb, err := uow.OrderRepository().Get(ctx, orderAggregate.Id())
+	assert.NoError(t, err)
+
+	// Проверяем эквивалентность
+	assert.Equal(t, address, orderFromDb.Address())
+	assert.ElementsMatch(t, []order.Item{firstItem, secondItem}, orderFromDb.Items())
+}
+
+func Test_OrderRepositoryShouldWriteStatusHistory(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Создаем UnitOfWork
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Заказ создаем, назначаем и сохраняем без изменений еще раз
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 10)
+	assert.NoError(t, err)
+	err = uow.OrderRepository().Add(ctx, orderAggregate)
+	assert.NoError(t, err)
+	assert.NoError(t, orderAggregate.AssignCourier(uuid.New()))
+	err = uow.OrderRepository().Update(ctx, orderAggregate)
+	assert.NoError(t, err)
+	err = uow.OrderRepository().Update(ctx, orderAggregate)
+	assert.NoError(t, err)
+
+	// Считываем заказ через запрос
+	getOrderQueryHandler, err := queries.NewGetOrderQueryHandler(db)
+	assert.NoError(t, err)
+	response, err := getOrderQueryHandler.Handle(queries.GetOrderQuery{OrderID: orderAggregate.Id()})
+	assert.NoError(t, err)
+
+	// В истории только реальные смены статуса
+	assert.Equal(t, order.StatusAssigned.String(), response.Status)
+	assert.Equal(t, orderAggregate.CourierId(), response.CourierID)
+	assert.Len(t, response.History, 2)
+	assert.Equal(t, order.StatusCreated.String(), response.History[0].Status)
+	assert.Equal(t, order.StatusAssigned.String(), response.History[1].Status)
+
+	_, err = getOrderQueryHandler.Handle(queries.GetOrderQuery{OrderID: uuid.New()})
+	assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func Test_CourierRepositoryShouldPersistStoredOrders(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Создаем UnitOfWork
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Курьер берет два заказа в одно место хранения
+	courierAggregate, err := courier.NewCourier("Велосипедист", courier.Bicycle, kernel.MinLocation())
+	assert.NoError(t, err)
+	assert.NoError(t, courierAggregate.AddStoragePlace("Сумка", 10))
+	assert.NoError(t, courierAggregate.StartShift())
+	err = uow.CourierRepository().Add(ctx, courierAggregate)
+	assert.NoError(t, err)
+
+	firstOrder, _ := order.NewOrder(uuid.New(), kernel.MaxLocation(), 2)
+	secondOrder, _ := order.NewOrder(uuid.New(), kernel.MaxLocation(), 3)
+	assert.NoError(t, courierAggregate.TakeOrder(firstOrder))
+	assert.NoError(t, courierAggregate.TakeOrder(secondOrder))
+	err = uow.CourierRepository().Update(ctx, courierAggregate)
+	assert.NoError(t, err)
+
+	// Считываем данные из БД
+	courierFromDb, err := uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{firstOrder.Id(), secondOrder.Id()}, courierFromDb.OrderIDs())
+	assert.Equal(t, courier.StatusOnShift, courierFromDb.Status())
+
+	// Отдаем один заказ
+	assert.NoError(t, courierFromDb.CompleteOrder(firstOrder))
+	err = uow.CourierRepository().Update(ctx, courierFromDb)
+	assert.NoError(t, err)
+
+	// Проверяем, что в БД остался только второй заказ
+	courierFromDb, err = uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{secondOrder.Id()}, courierFromDb.OrderIDs())
+}
+
+func Test_MigrateLegacyStoredOrdersShouldMoveOrderIDColumn(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Создаем UnitOfWork
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Курьер со старой схемой: заказ записан прямо в месте хранения
+	courierAggregate, err := courier.NewCourier("Велосипедист", courier.Bicycle, kernel.MinLocation())
+	assert.NoError(t, err)
+	assert.NoError(t, courierAggregate.AddStoragePlace("Сумка", 10))
+	err = uow.CourierRepository().Add(ctx, courierAggregate)
+	assert.NoError(t, err)
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MaxLocation(), 4)
+	assert.NoError(t, err)
+	err = uow.OrderRepository().Add(ctx, orderAggregate)
+	assert.NoError(t, err)
+
+	storagePlace := courierAggregate.StoragePlaces()[0]
+	err = db.Exec("ALTER TABLE storage_places ADD COLUMN order_id uuid").Error
+	assert.NoError(t, err)
+	err = db.Exec("UPDATE storage_places SET order_id = ? WHERE id = ?", orderAggregate.Id(), storagePlace.ID()).Error
+	assert.NoError(t, err)
+
+	// Переносим данные, повторный запуск ничего не меняет
+	assert.NoError(t, courierrepo.MigrateLegacyStoredOrders(db))
+	assert.NoError(t, courierrepo.MigrateLegacyStoredOrders(db))
+
+	// Старой колонки нет, заказ лежит в stored_orders со своим объемом
+	assert.False(t, db.Migrator().HasColumn(&courierrepo.StoragePlaceDTO{}, "order_id"))
+	var storedOrder courierrepo.StoredOrderDTO
+	err = db.First(&storedOrder, "order_id = ?", orderAggregate.Id()).Error
+	assert.NoError(t, err)
+	assert.Equal(t, storagePlace.ID(), storedOrder.StoragePlaceID)
+	assert.Equal(t, 4, storedOrder.Volume)
+
+	courierFromDb, err := uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{orderAggregate.Id()}, courierFromDb.OrderIDs())
+}
+
+func Test_GetAllCouriersQueryShouldPageAndFilter(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Пять курьеров со скоростями 1..5
+	for speed := 1; speed <= 5; speed++ {
+		transport, err := courier.NewTransport("Тест", speed, 15, 100)
+		assert.NoError(t, err)
+		courierAggregate, err := courier.NewCourier("Курьер", transport, kernel.MinLocation())
+		assert.NoError(t, err)
+		assert.NoError(t, uow.CourierRepository().Add(ctx, courierAggregate))
+	}
+
+	handler, err := queries.NewGetAllCouriersQueryHandler(db)
+	assert.NoError(t, err)
+
+	// Первая страница - двое самых быстрых курьеров со скоростью не больше 4
+	maxSpeed := 4
+	query := queries.GetAllCouriersQuery{
+		MaxSpeed:  &maxSpeed,
+		SortBy:    queries.CourierSortBySpeed,
+		SortOrder: queries.SortOrderDesc,
+		Limit:     2,
+	}
+	page, err := handler.Handle(query)
+	assert.NoError(t, err)
+	assert.Len(t, page.Couriers, 2)
+	assert.Equal(t, 4, page.Couriers[0].Speed)
+	assert.Equal(t, 3, page.Couriers[1].Speed)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// Вторая страница - оставшиеся, курсора дальше нет
+	query.Cursor = page.NextCursor
+	page, err = handler.Handle(query)
+	assert.NoError(t, err)
+	assert.Len(t, page.Couriers, 2)
+	assert.Equal(t, 2, page.Couriers[0].Speed)
+	assert.Equal(t, 1, page.Couriers[1].Speed)
+	assert.Empty(t, page.NextCursor)
+
+	// Курсор от другой сортировки не принимается
+	query.SortOrder = queries.SortOrderAsc
+	_, err = handler.Handle(query)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func Test_GetCourierQueryShouldReturnLoadAndCarriedOrders(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Курьер везет один заказ
+	courierAggregate, err := courier.NewCourier("Велосипедист", courier.Bicycle, kernel.MinLocation())
+	assert.NoError(t, err)
+	assert.NoError(t, courierAggregate.AddStoragePlace("Сумка", 10))
+	assert.NoError(t, courierAggregate.StartShift())
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MaxLocation(), 3)
+	assert.NoError(t, err)
+	assert.NoError(t, uow.OrderRepository().Add(ctx, orderAggregate))
+	assert.NoError(t, courierAggregate.TakeOrder(orderAggregate))
+	assert.NoError(t, uow.CourierRepository().Add(ctx, courierAggregate))
+
+	handler, err := queries.NewGetCourierQueryHandler(db, defaultZones(t), services.NewGridNavigator())
+	assert.NoError(t, err)
+	response, err := handler.Handle(queries.GetCourierQuery{CourierID: courierAggregate.ID()})
+	assert.NoError(t, err)
+
+	// Проверяем загрузку и ETA
+	assert.Equal(t, 2, response.Speed)
+	usedVolume := 0
+	for _, storagePlace := range response.StoragePlaces {
+		usedVolume += storagePlace.UsedVolume
+		assert.Equal(t, storagePlace.TotalVolume-storagePlace.UsedVolume, storagePlace.FreeVolume)
+	}
+	assert.Equal(t, 3, usedVolume)
+	assert.Len(t, response.Orders, 1)
+	expectedSteps, _ := courierAggregate.StepsTo(orderAggregate.Location())
+	assert.Equal(t, orderAggregate.Id(), response.Orders[0].ID)
+	assert.Equal(t, expectedSteps, *response.Orders[0].Steps)
+	assert.True(t, response.Orders[0].ETA.After(time.Now()))
+
+	// Несуществующий курьер
+	_, err = handler.Handle(queries.GetCourierQuery{CourierID: uuid.New()})
+	assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+}
+
+func Test_CourierRepositoryShouldDeleteRemovedStoragePlaces(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	courierAggregate, err := courier.NewCourier("Водитель", courier.Car, kernel.MinLocation())
+	assert.NoError(t, err)
+	assert.NoError(t, courierAggregate.AddStoragePlace("Сумка", 10))
+	assert.NoError(t, courierAggregate.AddStoragePlace("Багажник", 50))
+	err = uow.CourierRepository().Add(ctx, courierAggregate)
+	assert.NoError(t, err)
+
+	// Убираем сумку, багажник остается
+	assert.NoError(t, courierAggregate.RemoveStoragePlace(courierAggregate.StoragePlaces()[0].ID()))
+	err = uow.CourierRepository().Update(ctx, courierAggregate)
+	assert.NoError(t, err)
+
+	// В БД осталось только оно
+	courierFromDb, err := uow.CourierRepository().Get(ctx, courierAggregate.ID())
+	assert.NoError(t, err)
+	assert.Len(t, courierFromDb.StoragePlaces(), 1)
+	assert.Equal(t, "Багажник", courierFromDb.StoragePlaces()[0].Name())
+}
+
+// stubGeoClient всегда находит адрес в левом нижнем углу карты
+type stubGeoClient struct{}
+
+func (stubGeoClient) GetGeolocation(_ context.Context, _ string) (kernel.Location, kernel.GeoCoordinate, error) {
+	return kernel.MinLocation(), kernel.GeoCoordinate{}, nil
+}
+
+func Test_CreateOrderShouldProcessDuplicateMessagesOnce(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	basketID := uuid.New()
+	newCommand := func(offset int64) *commands.CreateOrderCommand {
+		command, err := commands.NewCreateOrderCommand(basketID, commands.OrderAddress{Street: "Тверская"},
+			nil, 5, time.Time{}, time.Time{})
+		assert.NoError(t, err)
+		command.Inbox = &inbox.Message{Topic: "basket.confirmed", Partition: 0, Offset: offset, BasketID: basketID}
+		return command
+	}
+
+	// Обработчик один на все партиции, как в консьюмере: UnitOfWork он берет свой на каждый вызов
+	handler, err := commands.NewCreateOrderCommandHandler(func() (ports.UnitOfWork, error) {
+		return NewUnitOfWork(db, defaultZones(t))
+	}, stubGeoClient{})
+	assert.NoError(t, err)
+
+	// Одно и то же сообщение доставили несколько раз одновременно
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Повторная доставка либо отсекается inbox, либо видит уже созданный такой же заказ
+			assert.NoError(t, handler.Handle(ctx, newCommand(42)))
+		}()
+	}
+	wg.Wait()
+
+	// Та же корзина пришла еще раз с другим смещением - заказ уже есть, повтор ничего не меняет
+	assert.NoError(t, handler.Handle(ctx, newCommand(43)))
+
+	// Вручную та же корзина с другим объемом - конфликт с созданным заказом
+	conflicting, err := commands.NewCreateOrderCommand(basketID, commands.OrderAddress{Street: "Тверская"},
+		nil, 7, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, handler.Handle(ctx, conflicting), commands.ErrOrderAlreadyExists)
+
+	// Заказ и отметка об обработке - по одной
+	var orders, messages int64
+	assert.NoError(t, db.Model(&orderrepo.OrderDTO{}).Where("id = ?", basketID).Count(&orders).Error)
+	assert.NoError(t, db.Model(&inbox.Message{}).Where("basket_id = ?", basketID).Count(&messages).Error)
+	assert.Equal(t, int64(1), orders)
+	assert.Equal(t, int64(1), messages)
+
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+	err = uow.InboxRepository().Add(ctx, &inbox.Message{Topic: "basket.confirmed", Offset: 42, BasketID: uuid.New()})
+	assert.ErrorIs(t, err, inbox.ErrAlreadyProcessed)
+}
+
+func Test_AssignOrdersShouldSkipOrderWhoseWindowCannotBeMet(t *testing.T) {
+	// Инициализируем окружение
+	ctx, db, err := setupTest(t)
+	assert.NoError(t, err)
+
+	// Создаем UnitOfWork
+	uow, err := NewUnitOfWork(db, defaultZones(t))
+	assert.NoError(t, err)
+
+	// Пешеход в углу карты
+	courierAggregate, err := courier.NewCourier("Пешеход", courier.Pedestrian, kernel.MinLocation())
+	assert.NoError(t, err)
+	assert.NoError(t, courierAggregate.AddStoragePlace("Сумка", 10))
+	assert.NoError(t, courierAggregate.StartShift())
+	assert.NoError(t, uow.CourierRepository().Add(ctx, courierAggregate))
+
+	// Первым в очереди стоит заказ в другом углу карты, окно которого закроется через 30 секунд -
+	// пешеход до него не успеет. За ним заказ без окна
+	now := time.Now().UTC()
+	unmeetable, err := order.NewOrder(uuid.New(), kernel.MaxLocation(), 5)
+	assert.NoError(t, err)
+	window, err := order.NewDeliveryWindow(now.Add(-time.Hour), now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.NoError(t, unmeetable.SetDeliveryWindow(window))
+	assert.NoError(t, uow.OrderRepository().Add(ctx, unmeetable))
+	regular, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 5)
+	assert.NoError(t, err)
+	assert.NoError(t, uow.OrderRepository().Add(ctx, regular))
+
+	handler, err := commands.NewAssignOrdersCommandHandler(func() (ports.UnitOfWork, error) {
+		return NewUnitOfWork(db, defaultZones(t))
+	}, services.NewDispatchService())
+	assert.NoError(t, err)
+
+	// Act
+	err = handler.Handle(ctx, &commands.AssignOrdersCommand{})
+
+	// Assert: первый заказ не блокирует очередь, курьер достается второму
+	assert.NoError(t, err)
+	regularFromDb, err := uow.OrderRepository().Get(ctx, regular.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, order.StatusAssigned, regularFromDb.Status())
+	assert.Equal(t, courierAggregate.ID(), *regularFromDb.CourierId())
+	unmeetableFromDb, err := uow.OrderRepository().Get(ctx, unmeetable.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, order.StatusCreated, unmeetableFromDb.Status())
+}
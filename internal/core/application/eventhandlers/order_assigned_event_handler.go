@@ -0,0 +1,29 @@
+package eventhandlers
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+)
+
+type orderAssignedDomainEventHandler struct {
+	orderProducer ports.OrderProducer
+}
+
+func NewOrderAssignedDomainEventHandler(
+	orderProducer ports.OrderProducer) (ddd.EventHandler, error) {
+	if orderProducer == nil {
+		return nil, errs.NewValueIsRequiredError("orderProducer")
+	}
+
+	return &orderAssignedDomainEventHandler{orderProducer: orderProducer}, nil
+}
+
+func (eh *orderAssignedDomainEventHandler) Handle(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	err := eh.orderProducer.Publish(ctx, domainEvent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package eventhandlers
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+)
+
+type orderCancelledDomainEventHandler struct {
+	orderProducer ports.OrderProducer
+}
+
+func NewOrderCancelledDomainEventHandler(
+	orderProducer ports.OrderProducer) (ddd.EventHandler, error) {
+	if orderProducer == nil {
+		return nil, errs.NewValueIsRequiredError("orderProducer")
+	}
+
+	return &orderCancelledDomainEventHandler{orderProducer: orderProducer}, nil
+}
+
+func (eh *orderCancelledDomainEventHandler) Handle(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	err := eh.orderProducer.Publish(ctx, domainEvent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
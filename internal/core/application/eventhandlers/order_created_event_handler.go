@@ -0,0 +1,29 @@
+package eventhandlers
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/errs"
+)
+
+type orderCreatedDomainEventHandler struct {
+	orderProducer ports.OrderProducer
+}
+
+func NewOrderCreatedDomainEventHandler(
+	orderProducer ports.OrderProducer) (ddd.EventHandler, error) {
+	if orderProducer == nil {
+		return nil, errs.NewValueIsRequiredError("orderProducer")
+	}
+
+	return &orderCreatedDomainEventHandler{orderProducer: orderProducer}, nil
+}
+
+func (eh *orderCreatedDomainEventHandler) Handle(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	err := eh.orderProducer.Publish(ctx, domainEvent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type AddStoragePlaceCommand struct {
+	CourierID   uuid.UUID
+	Name        string
+	TotalVolume int
+}
+
+func NewAddStoragePlaceCommand(courierID uuid.UUID, name string, totalVolume int) (*AddStoragePlaceCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courierID")
+	}
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+	if totalVolume <= 0 {
+		return nil, errs.NewValueIsRequiredError("totalVolume")
+	}
+
+	return &AddStoragePlaceCommand{
+		CourierID:   courierID,
+		Name:        name,
+		TotalVolume: totalVolume,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type AddStoragePlaceCommandHandler interface {
+	Handle(context.Context, *AddStoragePlaceCommand) error
+}
+
+var _ AddStoragePlaceCommandHandler = &addStoragePlaceCommandHandler{}
+
+type addStoragePlaceCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewAddStoragePlaceCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (AddStoragePlaceCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &addStoragePlaceCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *addStoragePlaceCommandHandler) Handle(ctx context.Context, command *AddStoragePlaceCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("add storage place command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	courier, err := unitOfWork.CourierRepository().Get(ctx, command.CourierID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	if err := courier.AddStoragePlace(command.Name, command.TotalVolume); err != nil {
+		return err
+	}
+
+	// Сохранили
+	return unitOfWork.CourierRepository().Update(ctx, courier)
+}
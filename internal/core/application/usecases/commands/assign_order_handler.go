@@ -6,6 +6,7 @@ import (
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
 	"errors"
+	"time"
 )
 
 var (
@@ -13,6 +14,10 @@ var (
 	NotAvailableCouriers = errors.New("not available couriers")
 )
 
+// assignOrdersCandidates - сколько первых заказов перебирается за тик. Если курьера не нашлось для первого
+// (например, его окно доставки никто не успевает), пробуем следующий, а не ждем на нем до бесконечности
+const assignOrdersCandidates = 20
+
 type AssignOrdersCommandHandler interface {
 	Handle(context.Context, *AssignOrdersCommand) error
 }
@@ -20,23 +25,23 @@ type AssignOrdersCommandHandler interface {
 var _ AssignOrdersCommandHandler = &assignOrdersCommandHandler{}
 
 type assignOrdersCommandHandler struct {
-	unitOfWork      ports.UnitOfWork
-	orderDispatcher services.DispatchService
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	orderDispatcher   services.DispatchService
 }
 
 func NewAssignOrdersCommandHandler(
-	unitOfWork ports.UnitOfWork,
+	unitOfWorkFactory ports.UnitOfWorkFactory,
 	orderDispatcher services.DispatchService) (AssignOrdersCommandHandler, error) {
-	if unitOfWork == nil {
-		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
 	}
 	if orderDispatcher == nil {
 		return nil, errs.NewValueIsRequiredError("orderDispatcher")
 	}
 
 	return &assignOrdersCommandHandler{
-		unitOfWork:      unitOfWork,
-		orderDispatcher: orderDispatcher,
+		unitOfWorkFactory: unitOfWorkFactory,
+		orderDispatcher:   orderDispatcher,
 	}, nil
 }
 
@@ -44,8 +49,12 @@ func (ch *assignOrdersCommandHandler) Handle(ctx context.Context, command *Assig
 	if command == nil {
 		return errs.NewValueIsRequiredError("assign orders command")
 	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
 
-	orderAggregate, err := ch.unitOfWork.OrderRepository().GetFirstInCreatedStatus(ctx)
+	orders, err := unitOfWork.OrderRepository().GetFirstInCreatedStatus(ctx, assignOrdersCandidates)
 	if err != nil {
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return NotAvailableOrders
@@ -53,7 +62,7 @@ func (ch *assignOrdersCommandHandler) Handle(ctx context.Context, command *Assig
 		return err
 	}
 
-	couriers, err := ch.unitOfWork.CourierRepository().GetAllFree(ctx)
+	couriers, err := unitOfWork.CourierRepository().GetAllFree(ctx)
 	if err != nil {
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return NotAvailableCouriers
@@ -64,19 +73,37 @@ func (ch *assignOrdersCommandHandler) Handle(ctx context.Context, command *Assig
 		return nil
 	}
 
-	courier, err := ch.orderDispatcher.Dispatch(orderAggregate, couriers)
-	if err != nil {
-		return err
-	}
+	now := time.Now()
+	for _, orderAggregate := range orders {
+		// Заказ, окно доставки которого закрылось, помечаем опоздавшим - даже если курьера для него не нашлось
+		markedLate := orderAggregate.MarkLate(now)
+
+		courier, err := ch.orderDispatcher.Dispatch(orderAggregate, couriers)
+		if err != nil {
+			if markedLate {
+				if err := unitOfWork.OrderRepository().Update(ctx, orderAggregate); err != nil {
+					return err
+				}
+			}
+			if errors.Is(err, services.ErrSuitableCourierWasNotFound) {
+				continue
+			}
+			return err
+		}
 
-	ch.unitOfWork.Begin(ctx)
+		unitOfWork.Begin(ctx)
+		defer func() {
+			_ = unitOfWork.Rollback()
+		}()
 
-	if err := ch.unitOfWork.OrderRepository().Update(ctx, orderAggregate); err != nil {
-		return err
-	}
-	if err := ch.unitOfWork.CourierRepository().Update(ctx, courier); err != nil {
-		return err
-	}
+		if err := unitOfWork.OrderRepository().Update(ctx, orderAggregate); err != nil {
+			return err
+		}
+		if err := unitOfWork.CourierRepository().Update(ctx, courier); err != nil {
+			return err
+		}
 
-	return ch.unitOfWork.Commit(ctx)
+		return unitOfWork.Commit(ctx)
+	}
+	return services.ErrSuitableCourierWasNotFound
 }
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/domain/sevices"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var _ AssignOrdersCommandHandler = &assignOrdersBatchCommandHandler{}
+
+// assignOrdersBatchCommandHandler за один запуск распределяет все созданные заказы между всеми свободными курьерами
+type assignOrdersBatchCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	orderDispatcher   services.DispatchService
+}
+
+func NewAssignOrdersBatchCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	orderDispatcher services.DispatchService) (AssignOrdersCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if orderDispatcher == nil {
+		return nil, errs.NewValueIsRequiredError("orderDispatcher")
+	}
+
+	return &assignOrdersBatchCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+		orderDispatcher:   orderDispatcher,
+	}, nil
+}
+
+func (ch *assignOrdersBatchCommandHandler) Handle(ctx context.Context, command *AssignOrdersCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("assign orders command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	orders, err := unitOfWork.OrderRepository().GetAllInCreatedStatus(ctx)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return NotAvailableOrders
+		}
+		return err
+	}
+
+	couriers, err := unitOfWork.CourierRepository().GetAllFree(ctx)
+	if err != nil {
+		if errors.Is(err, errs.ErrObjectNotFound) {
+			return NotAvailableCouriers
+		}
+		return err
+	}
+	if len(couriers) == 0 {
+		return nil
+	}
+
+	// Заказы, окно доставки которых закрылось, помечаем опоздавшими - даже если курьера для них не нашлось
+	now := time.Now()
+	changedOrders := make(map[uuid.UUID]*order.Order)
+	for _, createdOrder := range orders {
+		if createdOrder.MarkLate(now) {
+			changedOrders[createdOrder.Id()] = createdOrder
+		}
+	}
+
+	assignments, err := ch.orderDispatcher.DispatchAll(orders, couriers)
+	if err != nil {
+		return err
+	}
+	if len(assignments) == 0 && len(changedOrders) == 0 {
+		return nil
+	}
+
+	// Все назначения сохраняем в одной транзакции, каждого курьера - один раз
+	unitOfWork.Begin(ctx)
+	defer func() {
+		_ = unitOfWork.Rollback()
+	}()
+
+	changedCouriers := make(map[uuid.UUID]*courier.Courier)
+	for _, assignment := range assignments {
+		changedOrders[assignment.Order.Id()] = assignment.Order
+		changedCouriers[assignment.Courier.ID()] = assignment.Courier
+	}
+	for _, changedOrder := range changedOrders {
+		if err := unitOfWork.OrderRepository().Update(ctx, changedOrder); err != nil {
+			return err
+		}
+	}
+	for _, changedCourier := range changedCouriers {
+		if err := unitOfWork.CourierRepository().Update(ctx, changedCourier); err != nil {
+			return err
+		}
+	}
+
+	return unitOfWork.Commit(ctx)
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type CancelOrderCommand struct {
+	OrderID uuid.UUID
+	Reason  string
+}
+
+func NewCancelOrderCommand(orderID uuid.UUID, reason string) (*CancelOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("orderID")
+	}
+	if reason == "" {
+		return nil, errs.NewValueIsRequiredError("reason")
+	}
+
+	return &CancelOrderCommand{
+		OrderID: orderID,
+		Reason:  reason,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type CancelOrderCommandHandler interface {
+	Handle(context.Context, *CancelOrderCommand) error
+}
+
+var _ CancelOrderCommandHandler = &cancelOrderCommandHandler{}
+
+type cancelOrderCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewCancelOrderCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (CancelOrderCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &cancelOrderCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *cancelOrderCommandHandler) Handle(ctx context.Context, command *CancelOrderCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("cancel order command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	orderAggregate, err := unitOfWork.OrderRepository().Get(ctx, command.OrderID)
+	if err != nil {
+		return err
+	}
+	if orderAggregate == nil {
+		return errs.NewObjectNotFoundError("order", command.OrderID)
+	}
+	wasAssigned := orderAggregate.Status() == order.StatusAssigned
+
+	// Изменили
+	if err := orderAggregate.Cancel(command.Reason); err != nil {
+		return err
+	}
+
+	// Сохранили, вместе с освобожденным местом у курьера. Транзакция откатывается при любом выходе
+	// без Commit, в том числе при панике: после Commit откатывать уже нечего
+	unitOfWork.Begin(ctx)
+	defer func() {
+		_ = unitOfWork.Rollback()
+	}()
+
+	if wasAssigned {
+		courier, err := unitOfWork.CourierRepository().Get(ctx, *orderAggregate.CourierId())
+		if err != nil {
+			return err
+		}
+		if err := courier.ReleaseOrder(orderAggregate); err != nil {
+			return err
+		}
+		if err := unitOfWork.CourierRepository().Update(ctx, courier); err != nil {
+			return err
+		}
+	}
+	if err := unitOfWork.OrderRepository().Update(ctx, orderAggregate); err != nil {
+		return err
+	}
+
+	return unitOfWork.Commit(ctx)
+}
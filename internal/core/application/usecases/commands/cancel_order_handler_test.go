@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/ports"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errCourierStorage = errors.New("courier storage is unavailable")
+
+// storedOrderRepository отдает заказ, который уже есть в хранилище
+type storedOrderRepository struct {
+	ports.OrderRepository
+	order *order.Order
+}
+
+func (r storedOrderRepository) Get(_ context.Context, _ uuid.UUID) (*order.Order, error) {
+	return r.order, nil
+}
+
+// failingCourierRepository не может прочитать курьера, когда транзакция уже открыта
+type failingCourierRepository struct {
+	ports.CourierRepository
+}
+
+func (failingCourierRepository) Get(_ context.Context, _ uuid.UUID) (*courier.Courier, error) {
+	return nil, errCourierStorage
+}
+
+type cancelUnitOfWork struct {
+	fakeUnitOfWork
+	order *order.Order
+}
+
+func (u *cancelUnitOfWork) OrderRepository() ports.OrderRepository {
+	return storedOrderRepository{order: u.order}
+}
+
+func (u *cancelUnitOfWork) CourierRepository() ports.CourierRepository {
+	return failingCourierRepository{}
+}
+
+func Test_CancelOrder_RollsBackWhenCourierCannotBeLoaded(t *testing.T) {
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 5)
+	assert.NoError(t, err)
+	assert.NoError(t, orderAggregate.AssignCourier(uuid.New()))
+	unitOfWork := &cancelUnitOfWork{order: orderAggregate}
+	handler, err := NewCancelOrderCommandHandler(func() (ports.UnitOfWork, error) {
+		return unitOfWork, nil
+	})
+	assert.NoError(t, err)
+	command, err := NewCancelOrderCommand(orderAggregate.Id(), "передумал")
+	assert.NoError(t, err)
+
+	err = handler.Handle(context.Background(), command)
+
+	assert.ErrorIs(t, err, errCourierStorage)
+	assert.False(t, unitOfWork.inTx)
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type ShiftAction string
+
+const (
+	ShiftActionStart      ShiftAction = "start-shift"
+	ShiftActionEnd        ShiftAction = "end-shift"
+	ShiftActionStartBreak ShiftAction = "start-break"
+	ShiftActionEndBreak   ShiftAction = "end-break"
+)
+
+type ChangeCourierShiftCommand struct {
+	CourierID uuid.UUID
+	Action    ShiftAction
+}
+
+func NewChangeCourierShiftCommand(courierID uuid.UUID, action ShiftAction) (*ChangeCourierShiftCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courierID")
+	}
+	switch action {
+	case ShiftActionStart, ShiftActionEnd, ShiftActionStartBreak, ShiftActionEndBreak:
+	default:
+		return nil, errs.NewValueIsInvalidError("action")
+	}
+
+	return &ChangeCourierShiftCommand{
+		CourierID: courierID,
+		Action:    action,
+	}, nil
+}
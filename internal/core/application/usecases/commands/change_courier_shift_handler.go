@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type ChangeCourierShiftCommandHandler interface {
+	Handle(context.Context, *ChangeCourierShiftCommand) error
+}
+
+var _ ChangeCourierShiftCommandHandler = &changeCourierShiftCommandHandler{}
+
+type changeCourierShiftCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewChangeCourierShiftCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (ChangeCourierShiftCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &changeCourierShiftCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *changeCourierShiftCommandHandler) Handle(ctx context.Context, command *ChangeCourierShiftCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("change courier shift command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	courier, err := unitOfWork.CourierRepository().Get(ctx, command.CourierID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	switch command.Action {
+	case ShiftActionStart:
+		err = courier.StartShift()
+	case ShiftActionEnd:
+		err = courier.EndShift()
+	case ShiftActionStartBreak:
+		err = courier.StartBreak()
+	case ShiftActionEndBreak:
+		err = courier.EndBreak()
+	default:
+		err = errs.NewValueIsInvalidError("action")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Сохранили
+	return unitOfWork.CourierRepository().Update(ctx, courier)
+}
@@ -1,24 +1,57 @@
 package commands
 
 import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/pkg/errs"
 )
 
 type CreateCourierCommand struct {
-	Name  string
-	Speed int
+	Name      string
+	Transport courier.Transport
+
+	// Location - стартовая точка курьера, nil - случайная точка на карте
+	Location *kernel.Location
+
+	// Coordinate - стартовые координаты курьера, пустые - курьер ездит только по сетке
+	Coordinate kernel.GeoCoordinate
+
+	// StoragePlaces - места хранения курьера, пустой список - сумка случайного объема
+	StoragePlaces []CourierStoragePlace
 }
 
-func NewCreateCourierCommand(name string, speed int) (*CreateCourierCommand, error) {
+type CourierStoragePlace struct {
+	Name        string
+	TotalVolume int
+}
+
+func NewCreateCourierCommand(name string, transport courier.Transport, location *kernel.Location, coordinate kernel.GeoCoordinate,
+	storagePlaces []CourierStoragePlace) (*CreateCourierCommand, error) {
 	if name == "" {
 		return nil, errs.NewValueIsRequiredError("name")
 	}
-	if speed <= 0 {
-		return nil, errs.NewValueIsRequiredError("speed")
+	if transport.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("transport")
+	}
+	if location != nil {
+		if err := location.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+	for _, storagePlace := range storagePlaces {
+		if storagePlace.Name == "" {
+			return nil, errs.NewValueIsRequiredError("storagePlace.name")
+		}
+		if storagePlace.TotalVolume <= 0 {
+			return nil, errs.NewValueIsRequiredError("storagePlace.totalVolume")
+		}
 	}
 
 	return &CreateCourierCommand{
-		Name:  name,
-		Speed: speed,
+		Name:          name,
+		Transport:     transport,
+		Location:      location,
+		Coordinate:    coordinate,
+		StoragePlaces: storagePlaces,
 	}, nil
 }
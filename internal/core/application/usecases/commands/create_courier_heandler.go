@@ -15,18 +15,31 @@ type CreateCourierCommandHandler interface {
 var _ CreateCourierCommandHandler = &createCourierCommandHandler{}
 
 type createCourierCommandHandler struct {
-	unitOfWork ports.UnitOfWork
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	random            kernel.RandomSource
+	grid              kernel.Grid
 }
 
+// NewCreateCourierCommandHandler создает обработчик, который расставляет курьеров на карте grid
 func NewCreateCourierCommandHandler(
-	unitOfWork ports.UnitOfWork,
+	unitOfWorkFactory ports.UnitOfWorkFactory,
+	random kernel.RandomSource,
+	grid kernel.Grid,
 ) (CreateCourierCommandHandler, error) {
-	if unitOfWork == nil {
-		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if random == nil {
+		return nil, errs.NewValueIsRequiredError("random")
+	}
+	if grid.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("grid")
 	}
 
 	return &createCourierCommandHandler{
-		unitOfWork: unitOfWork,
+		unitOfWorkFactory: unitOfWorkFactory,
+		random:            random,
+		grid:              grid,
 	}, nil
 }
 
@@ -34,13 +47,46 @@ func (ch *createCourierCommandHandler) Handle(ctx context.Context, command *Crea
 	if command == nil {
 		return errs.NewValueIsRequiredError("create courier command")
 	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
 
-	location, _ := kernel.CreateRandom()
+	var location kernel.Location
+	if command.Location != nil {
+		if !command.Location.Grid().Equals(ch.grid) {
+			return errs.NewValueIsInvalidError("location")
+		}
+		location = *command.Location
+	} else {
+		randomLocation, err := ch.grid.CreateRandom(ch.random)
+		if err != nil {
+			return err
+		}
+		location = randomLocation
+	}
 
-	courierAggregate, err := courier.NewCourier(command.Name, command.Speed, location)
+	courierAggregate, err := courier.NewCourier(command.Name, command.Transport, location)
 	if err != nil {
 		return err
 	}
 
-	return ch.unitOfWork.CourierRepository().Add(ctx, courierAggregate)
+	if !command.Coordinate.IsEmpty() {
+		if err := courierAggregate.SetCoordinate(command.Coordinate); err != nil {
+			return err
+		}
+	}
+
+	if len(command.StoragePlaces) == 0 {
+		if err := courierAggregate.AddDefaultBag(ch.random); err != nil {
+			return err
+		}
+	}
+	for _, storagePlace := range command.StoragePlaces {
+		if err := courierAggregate.AddStoragePlace(storagePlace.Name, storagePlace.TotalVolume); err != nil {
+			return err
+		}
+	}
+
+	return unitOfWork.CourierRepository().Add(ctx, courierAggregate)
 }
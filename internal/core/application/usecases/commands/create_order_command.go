@@ -2,26 +2,63 @@ package commands
 
 import (
 	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/inbox"
 	"github.com/google/uuid"
+	"time"
 )
 
 type CreateOrderCommand struct {
 	OrderID uuid.UUID
-	Street  string
+	Address OrderAddress
+	Items   []OrderItem
 	Volume  int
+
+	// Окно доставки, нулевые значения - без ограничений по времени
+	DeliveryFrom time.Time
+	DeliveryTo   time.Time
+
+	// Inbox - сообщение из очереди, ради которого создается заказ, nil - заказ пришел не из очереди
+	Inbox *inbox.Message
 }
 
-func NewCreateOrderCommand(orderID uuid.UUID, street string, volume int) (*CreateOrderCommand, error) {
-	if street == "" {
+type OrderAddress struct {
+	Country   string
+	City      string
+	Street    string
+	House     string
+	Apartment string
+}
+
+type OrderItem struct {
+	ID       uuid.UUID
+	GoodID   uuid.UUID
+	Title    string
+	Price    float64
+	Quantity int
+}
+
+func NewCreateOrderCommand(orderID uuid.UUID, address OrderAddress, items []OrderItem, volume int,
+	deliveryFrom time.Time, deliveryTo time.Time) (*CreateOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("orderID")
+	}
+	if address.Street == "" {
 		return nil, errs.NewValueIsRequiredError("street")
 	}
 	if volume <= 0 {
 		return nil, errs.NewValueIsRequiredError("volume")
 	}
+	if deliveryFrom.IsZero() != deliveryTo.IsZero() || deliveryTo.Before(deliveryFrom) {
+		return nil, errs.NewValueIsInvalidError("deliveryPeriod")
+	}
 
 	return &CreateOrderCommand{
-		OrderID: uuid.New(),
-		Street:  street,
+		OrderID: orderID,
+		Address: address,
+		Items:   items,
 		Volume:  volume,
+
+		DeliveryFrom: deliveryFrom,
+		DeliveryTo:   deliveryTo,
 	}, nil
 }
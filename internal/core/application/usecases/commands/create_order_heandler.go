@@ -2,11 +2,18 @@ package commands
 
 import (
 	"context"
+	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/domain/model/order"
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
+	"delivery/internal/pkg/inbox"
+	"errors"
 )
 
+// ErrOrderAlreadyExists - заказ для этой корзины уже создан с другими данными. Повтор того же запроса
+// ошибкой не считается: заказ уже есть, и обработчик ничего не делает
+var ErrOrderAlreadyExists = errors.New("order for this basket already exists")
+
 type CreateOrderCommandHandler interface {
 	Handle(context.Context, *CreateOrderCommand) error
 }
@@ -14,13 +21,15 @@ type CreateOrderCommandHandler interface {
 var _ CreateOrderCommandHandler = &createOrderCommandHandler{}
 
 type createOrderCommandHandler struct {
-	unitOfWork ports.UnitOfWork
-	geoClient  ports.GeoClient
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	geoClient         ports.GeoClient
 }
 
-func NewCreateOrderCommandHandler(unitOfWork ports.UnitOfWork, geoClient ports.GeoClient) (CreateOrderCommandHandler, error) {
-	if unitOfWork == nil {
-		return nil, errs.NewValueIsRequiredError("unitOfWork")
+// NewCreateOrderCommandHandler создает обработчик, который можно вызывать одновременно из нескольких горутин:
+// каждый вызов работает в своем UnitOfWork
+func NewCreateOrderCommandHandler(unitOfWorkFactory ports.UnitOfWorkFactory, geoClient ports.GeoClient) (CreateOrderCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
 	}
 
 	if geoClient == nil {
@@ -28,24 +37,42 @@ func NewCreateOrderCommandHandler(unitOfWork ports.UnitOfWork, geoClient ports.G
 	}
 
 	return &createOrderCommandHandler{
-		unitOfWork: unitOfWork,
-		geoClient:  geoClient}, nil
+		unitOfWorkFactory: unitOfWorkFactory,
+		geoClient:         geoClient}, nil
 }
 
 func (ch *createOrderCommandHandler) Handle(ctx context.Context, command *CreateOrderCommand) error {
 	if command == nil {
 		return errs.NewValueIsRequiredError("create order command")
 	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
 
-	existingOrder, err := ch.unitOfWork.OrderRepository().Get(ctx, command.OrderID)
+	// Быстрая проверка, чтобы не ходить в геосервис за дублем. Окончательно дубли отсекает вставка в inbox
+	if command.Inbox != nil {
+		processed, err := unitOfWork.InboxRepository().Exists(ctx, command.Inbox)
+		if err != nil {
+			return err
+		}
+		if processed {
+			return nil
+		}
+	}
+
+	existingOrder, err := unitOfWork.OrderRepository().Get(ctx, command.OrderID)
 	if err != nil {
 		return err
 	}
 	if existingOrder != nil {
+		if !matchesCommand(existingOrder, command) {
+			return ErrOrderAlreadyExists
+		}
 		return nil
 	}
 
-	location, err := ch.geoClient.GetGeolocation(ctx, command.Street)
+	location, coordinate, err := ch.geoClient.GetGeolocation(ctx, command.Address.Street)
 	if err != nil {
 		return err
 	}
@@ -55,5 +82,95 @@ func (ch *createOrderCommandHandler) Handle(ctx context.Context, command *Create
 		return err
 	}
 
-	return ch.unitOfWork.OrderRepository().Add(ctx, newOrder)
+	if !coordinate.IsEmpty() {
+		if err := newOrder.SetCoordinate(coordinate); err != nil {
+			return err
+		}
+	}
+
+	address, err := kernel.NewAddress(command.Address.Country, command.Address.City, command.Address.Street,
+		command.Address.House, command.Address.Apartment)
+	if err != nil {
+		return err
+	}
+	if err := newOrder.SetAddress(address); err != nil {
+		return err
+	}
+	for _, commandItem := range command.Items {
+		item, err := order.NewItem(commandItem.ID, commandItem.GoodID, commandItem.Title,
+			commandItem.Price, commandItem.Quantity)
+		if err != nil {
+			return err
+		}
+		if err := newOrder.AddItem(item); err != nil {
+			return err
+		}
+	}
+
+	if !command.DeliveryFrom.IsZero() {
+		deliveryWindow, err := order.NewDeliveryWindow(command.DeliveryFrom, command.DeliveryTo)
+		if err != nil {
+			return err
+		}
+		if err := newOrder.SetDeliveryWindow(deliveryWindow); err != nil {
+			return err
+		}
+	}
+
+	// Сохранили заказ вместе с отметкой об обработке сообщения. Транзакция откатывается при любом выходе
+	// без Commit, в том числе при панике: после Commit откатывать уже нечего
+	unitOfWork.Begin(ctx)
+	defer func() {
+		_ = unitOfWork.Rollback()
+	}()
+	if command.Inbox != nil {
+		err := unitOfWork.InboxRepository().Add(ctx, command.Inbox)
+		if errors.Is(err, inbox.ErrAlreadyProcessed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := unitOfWork.OrderRepository().Add(ctx, newOrder); err != nil {
+		return err
+	}
+	return unitOfWork.Commit(ctx)
+}
+
+// matchesCommand - заказ создан таким же запросом: совпадают адрес, объем, окно доставки и товары.
+// Товары сравниваются, только если они есть в команде: вручную заказ создается без товаров
+func matchesCommand(existingOrder *order.Order, command *CreateOrderCommand) bool {
+	address := existingOrder.Address()
+	if address.Country() != command.Address.Country || address.City() != command.Address.City ||
+		address.Street() != command.Address.Street || address.House() != command.Address.House ||
+		address.Apartment() != command.Address.Apartment {
+		return false
+	}
+	if existingOrder.Volume() != command.Volume {
+		return false
+	}
+
+	window := existingOrder.DeliveryWindow()
+	if window.IsEmpty() != command.DeliveryFrom.IsZero() {
+		return false
+	}
+	if !window.IsEmpty() && (!window.From().Equal(command.DeliveryFrom) || !window.To().Equal(command.DeliveryTo)) {
+		return false
+	}
+
+	if len(command.Items) == 0 {
+		return true
+	}
+	items := existingOrder.Items()
+	if len(items) != len(command.Items) {
+		return false
+	}
+	for i, item := range items {
+		commandItem := command.Items[i]
+		if item.ID() != commandItem.ID || item.GoodID() != commandItem.GoodID || item.Quantity() != commandItem.Quantity {
+			return false
+		}
+	}
+	return true
 }
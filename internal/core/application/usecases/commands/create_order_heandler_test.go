@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeGeoClient struct{}
+
+func (fakeGeoClient) GetGeolocation(_ context.Context, _ string) (kernel.Location, kernel.GeoCoordinate, error) {
+	return kernel.MinLocation(), kernel.GeoCoordinate{}, nil
+}
+
+// panickingOrderRepository падает на вставке, когда транзакция уже открыта
+type panickingOrderRepository struct {
+	ports.OrderRepository
+}
+
+func (panickingOrderRepository) Get(_ context.Context, _ uuid.UUID) (*order.Order, error) {
+	return nil, nil
+}
+
+func (panickingOrderRepository) Add(_ context.Context, _ *order.Order) error {
+	panic("unexpected nil")
+}
+
+type fakeUnitOfWork struct {
+	ports.UnitOfWork
+	inTx bool
+}
+
+func (u *fakeUnitOfWork) Begin(_ context.Context) {
+	u.inTx = true
+}
+
+func (u *fakeUnitOfWork) Rollback() error {
+	u.inTx = false
+	return nil
+}
+
+func (u *fakeUnitOfWork) OrderRepository() ports.OrderRepository {
+	return panickingOrderRepository{}
+}
+
+func Test_CreateOrder_RollsBackWhenHandlerPanics(t *testing.T) {
+	unitOfWork := &fakeUnitOfWork{}
+	handler, err := NewCreateOrderCommandHandler(func() (ports.UnitOfWork, error) {
+		return unitOfWork, nil
+	}, fakeGeoClient{})
+	assert.NoError(t, err)
+	command, err := NewCreateOrderCommand(uuid.New(), OrderAddress{Street: "Тверская"}, nil, 5,
+		time.Time{}, time.Time{})
+	assert.NoError(t, err)
+
+	assert.Panics(t, func() { _ = handler.Handle(context.Background(), command) })
+	assert.False(t, unitOfWork.inTx)
+}
+
+// existingOrderUnitOfWork находит заказ, уже созданный для корзины
+type existingOrderUnitOfWork struct {
+	fakeUnitOfWork
+	order *order.Order
+}
+
+func (u *existingOrderUnitOfWork) OrderRepository() ports.OrderRepository {
+	return storedOrderRepository{order: u.order}
+}
+
+func Test_CreateOrder_RepeatedRequestIsIdempotent(t *testing.T) {
+	address := OrderAddress{City: "Москва", Street: "Тверская", House: "1"}
+	existingOrder, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 5)
+	assert.NoError(t, err)
+	existingAddress, err := kernel.NewAddress("", address.City, address.Street, address.House, "")
+	assert.NoError(t, err)
+	assert.NoError(t, existingOrder.SetAddress(existingAddress))
+
+	handler, err := NewCreateOrderCommandHandler(func() (ports.UnitOfWork, error) {
+		return &existingOrderUnitOfWork{order: existingOrder}, nil
+	}, fakeGeoClient{})
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		address OrderAddress
+		volume  int
+		from    time.Time
+		to      time.Time
+		want    error
+	}{
+		"same payload":     {address: address, volume: 5},
+		"different volume": {address: address, volume: 7, want: ErrOrderAlreadyExists},
+		"different address": {
+			address: OrderAddress{City: "Москва", Street: "Арбат", House: "1"}, volume: 5,
+			want: ErrOrderAlreadyExists,
+		},
+		"added delivery window": {
+			address: address, volume: 5,
+			from: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), to: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			want: ErrOrderAlreadyExists,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			command, err := NewCreateOrderCommand(existingOrder.Id(), test.address, nil, test.volume, test.from, test.to)
+			assert.NoError(t, err)
+
+			err = handler.Handle(context.Background(), command)
+
+			if test.want != nil {
+				assert.ErrorIs(t, err, test.want)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type DeactivateCourierCommand struct {
+	CourierID uuid.UUID
+}
+
+func NewDeactivateCourierCommand(courierID uuid.UUID) (*DeactivateCourierCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courierID")
+	}
+
+	return &DeactivateCourierCommand{
+		CourierID: courierID,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type DeactivateCourierCommandHandler interface {
+	Handle(context.Context, *DeactivateCourierCommand) error
+}
+
+var _ DeactivateCourierCommandHandler = &deactivateCourierCommandHandler{}
+
+type deactivateCourierCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewDeactivateCourierCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (DeactivateCourierCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &deactivateCourierCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *deactivateCourierCommandHandler) Handle(ctx context.Context, command *DeactivateCourierCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("deactivate courier command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	courier, err := unitOfWork.CourierRepository().Get(ctx, command.CourierID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	if err := courier.Deactivate(); err != nil {
+		return err
+	}
+
+	// Сохранили
+	return unitOfWork.CourierRepository().Update(ctx, courier)
+}
@@ -2,9 +2,15 @@ package commands
 
 import (
 	"context"
+	courierdomain "delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	services "delivery/internal/core/domain/sevices"
 	"delivery/internal/core/ports"
 	"delivery/internal/pkg/errs"
 	"errors"
+	"github.com/google/uuid"
+	"time"
 )
 
 type MoveCouriersCommandHandler interface {
@@ -14,26 +20,35 @@ type MoveCouriersCommandHandler interface {
 var _ MoveCouriersCommandHandler = &moveCouriersCommandHandler{}
 
 type moveCouriersCommandHandler struct {
-	unitOfWork ports.UnitOfWork
+	unitOfWorkFactory ports.UnitOfWorkFactory
+	navigator         services.Navigator
 }
 
 func NewMoveCouriersCommandHandler(
-	unitOfWork ports.UnitOfWork) (MoveCouriersCommandHandler, error) {
-	if unitOfWork == nil {
-		return nil, errs.NewValueIsRequiredError("unitOfWork")
+	unitOfWorkFactory ports.UnitOfWorkFactory, navigator services.Navigator) (MoveCouriersCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
 	}
 
 	return &moveCouriersCommandHandler{
-		unitOfWork: unitOfWork}, nil
+		unitOfWorkFactory: unitOfWorkFactory,
+		navigator:         navigator}, nil
 }
 
 func (ch *moveCouriersCommandHandler) Handle(ctx context.Context, command *MoveCouriersCommand) error {
 	if command == nil {
 		return errs.NewValueIsRequiredError("add address command")
 	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
 
 	// Восстановили
-	assignedOrders, err := ch.unitOfWork.OrderRepository().GetAllInAssignedStatus(ctx)
+	assignedOrders, err := unitOfWork.OrderRepository().GetAllInAssignedStatus(ctx)
 	if err != nil {
 		if errors.Is(err, errs.ErrObjectNotFound) {
 			return nil
@@ -41,10 +56,35 @@ func (ch *moveCouriersCommandHandler) Handle(ctx context.Context, command *MoveC
 		return err
 	}
 
-	// Изменили и сохранили
-	ch.unitOfWork.Begin(ctx)
+	// Сгруппировали заказы по курьерам, заодно отметили опоздавшие
+	now := time.Now()
+	lateOrders := make([]*order.Order, 0)
+	courierIDs := make([]uuid.UUID, 0)
+	ordersByCourier := make(map[uuid.UUID][]*order.Order)
 	for _, assignedOrder := range assignedOrders {
-		courier, err := ch.unitOfWork.CourierRepository().Get(ctx, *assignedOrder.CourierId())
+		if assignedOrder.MarkLate(now) {
+			lateOrders = append(lateOrders, assignedOrder)
+		}
+		courierID := *assignedOrder.CourierId()
+		if _, ok := ordersByCourier[courierID]; !ok {
+			courierIDs = append(courierIDs, courierID)
+		}
+		ordersByCourier[courierID] = append(ordersByCourier[courierID], assignedOrder)
+	}
+
+	// Изменили и сохранили
+	unitOfWork.Begin(ctx)
+	defer func() {
+		_ = unitOfWork.Rollback()
+	}()
+	for _, lateOrder := range lateOrders {
+		err := unitOfWork.OrderRepository().Update(ctx, lateOrder)
+		if err != nil {
+			return err
+		}
+	}
+	for _, courierID := range courierIDs {
+		courier, err := unitOfWork.CourierRepository().Get(ctx, courierID)
 		if err != nil {
 			if errors.Is(err, errs.ErrObjectNotFound) {
 				return nil
@@ -52,32 +92,52 @@ func (ch *moveCouriersCommandHandler) Handle(ctx context.Context, command *MoveC
 			return err
 		}
 
-		err = courier.StepTowards(assignedOrder.Location())
+		// На перерыве курьер стоит на месте
+		if courier.Status() == courierdomain.StatusOnBreak {
+			continue
+		}
+
+		// Курьер везет заказы по очереди, начиная с ближайшего
+		courierOrders := ordersByCourier[courierID]
+		nextOrder, err := ch.navigator.NearestOrder(courier, courierOrders)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			// До заказов не доехать, пока не откроют перекрытые клетки - курьер ждет на месте
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = ch.navigator.StepTowards(courier, nextOrder)
 		if err != nil {
 			return err
 		}
 
-		if courier.Location().Equals(assignedOrder.Location()) {
-			err := assignedOrder.Complete()
+		// Отдаем все заказы, до которых курьер добрался
+		for _, courierOrder := range courierOrders {
+			if !ch.navigator.HasArrived(courier, courierOrder) {
+				continue
+			}
+			err := courierOrder.Complete()
+			if err != nil {
+				return err
+			}
+			err = courier.CompleteOrder(courierOrder)
 			if err != nil {
 				return err
 			}
-			err = courier.CompleteOrder(assignedOrder)
+			err = unitOfWork.OrderRepository().Update(ctx, courierOrder)
 			if err != nil {
 				return err
 			}
 		}
 
-		err = ch.unitOfWork.OrderRepository().Update(ctx, assignedOrder)
-		if err != nil {
-			return err
-		}
-		err = ch.unitOfWork.CourierRepository().Update(ctx, courier)
+		err = unitOfWork.CourierRepository().Update(ctx, courier)
 		if err != nil {
 			return err
 		}
 	}
-	err = ch.unitOfWork.Commit(ctx)
+	err = unitOfWork.Commit(ctx)
 	if err != nil {
 		return err
 	}
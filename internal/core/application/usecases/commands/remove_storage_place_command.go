@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type RemoveStoragePlaceCommand struct {
+	CourierID      uuid.UUID
+	StoragePlaceID uuid.UUID
+}
+
+func NewRemoveStoragePlaceCommand(courierID uuid.UUID, storagePlaceID uuid.UUID) (*RemoveStoragePlaceCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courierID")
+	}
+	if storagePlaceID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("storagePlaceID")
+	}
+
+	return &RemoveStoragePlaceCommand{
+		CourierID:      courierID,
+		StoragePlaceID: storagePlaceID,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+	"errors"
+)
+
+type RemoveStoragePlaceCommandHandler interface {
+	Handle(context.Context, *RemoveStoragePlaceCommand) error
+}
+
+var _ RemoveStoragePlaceCommandHandler = &removeStoragePlaceCommandHandler{}
+
+type removeStoragePlaceCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewRemoveStoragePlaceCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (RemoveStoragePlaceCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &removeStoragePlaceCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *removeStoragePlaceCommandHandler) Handle(ctx context.Context, command *RemoveStoragePlaceCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("remove storage place command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	courierAggregate, err := unitOfWork.CourierRepository().Get(ctx, command.CourierID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	err = courierAggregate.RemoveStoragePlace(command.StoragePlaceID)
+	if errors.Is(err, courier.ErrStoragePlaceNotFound) {
+		return errs.NewObjectNotFoundErrorWithCause("storagePlace", command.StoragePlaceID.String(), err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Сохранили
+	return unitOfWork.CourierRepository().Update(ctx, courierAggregate)
+}
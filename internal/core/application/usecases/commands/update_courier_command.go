@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// UpdateCourierCommand меняет имя и/или транспорт курьера, nil - поле не меняется
+type UpdateCourierCommand struct {
+	CourierID uuid.UUID
+	Name      *string
+	Transport *courier.Transport
+}
+
+func NewUpdateCourierCommand(courierID uuid.UUID, name *string,
+	transport *courier.Transport) (*UpdateCourierCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courierID")
+	}
+	if name == nil && transport == nil {
+		return nil, errs.NewValueIsRequiredError("name or transport")
+	}
+	if name != nil && *name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+	if transport != nil && transport.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("transport")
+	}
+
+	return &UpdateCourierCommand{
+		CourierID: courierID,
+		Name:      name,
+		Transport: transport,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"delivery/internal/core/ports"
+	"delivery/internal/pkg/errs"
+)
+
+type UpdateCourierCommandHandler interface {
+	Handle(context.Context, *UpdateCourierCommand) error
+}
+
+var _ UpdateCourierCommandHandler = &updateCourierCommandHandler{}
+
+type updateCourierCommandHandler struct {
+	unitOfWorkFactory ports.UnitOfWorkFactory
+}
+
+func NewUpdateCourierCommandHandler(
+	unitOfWorkFactory ports.UnitOfWorkFactory) (UpdateCourierCommandHandler, error) {
+	if unitOfWorkFactory == nil {
+		return nil, errs.NewValueIsRequiredError("unitOfWorkFactory")
+	}
+
+	return &updateCourierCommandHandler{
+		unitOfWorkFactory: unitOfWorkFactory,
+	}, nil
+}
+
+func (ch *updateCourierCommandHandler) Handle(ctx context.Context, command *UpdateCourierCommand) error {
+	if command == nil {
+		return errs.NewValueIsRequiredError("update courier command")
+	}
+	unitOfWork, err := ch.unitOfWorkFactory()
+	if err != nil {
+		return err
+	}
+
+	// Восстановили
+	courier, err := unitOfWork.CourierRepository().Get(ctx, command.CourierID)
+	if err != nil {
+		return err
+	}
+
+	// Изменили
+	if command.Name != nil {
+		if err := courier.Rename(*command.Name); err != nil {
+			return err
+		}
+	}
+	if command.Transport != nil {
+		if err := courier.ChangeTransport(*command.Transport); err != nil {
+			return err
+		}
+	}
+
+	// Сохранили
+	return unitOfWork.CourierRepository().Update(ctx, courier)
+}
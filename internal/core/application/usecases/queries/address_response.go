@@ -0,0 +1,13 @@
+package queries
+
+type AddressResponse struct {
+	Country   string
+	City      string
+	Street    string
+	House     string
+	Apartment string
+}
+
+func (a AddressResponse) IsEmpty() bool {
+	return a == AddressResponse{}
+}
@@ -0,0 +1,16 @@
+package queries
+
+import "delivery/internal/core/domain/model/kernel"
+
+// CoordinateResponse - географические координаты, NULL - не заданы
+type CoordinateResponse struct {
+	Latitude  *float64
+	Longitude *float64
+}
+
+func (c CoordinateResponse) toDomain() (kernel.GeoCoordinate, error) {
+	if c.Latitude == nil || c.Longitude == nil {
+		return kernel.GeoCoordinate{}, nil
+	}
+	return kernel.NewGeoCoordinate(*c.Latitude, *c.Longitude)
+}
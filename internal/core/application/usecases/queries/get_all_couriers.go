@@ -1,21 +1,57 @@
 package queries
 
 import (
+	"delivery/internal/core/domain/model/courier"
 	"delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strconv"
 )
 
-type GetAllCouriersQuery struct{}
+type CourierSortField string
+
+const (
+	CourierSortByID    CourierSortField = "id"
+	CourierSortByName  CourierSortField = "name"
+	CourierSortBySpeed CourierSortField = "speed"
+)
+
+type GetAllCouriersQuery struct {
+	// Фильтры, nil - без ограничения
+	Busy     *bool
+	MinSpeed *int
+	MaxSpeed *int
+	Area     *LocationArea
+
+	SortBy    CourierSortField
+	SortOrder SortOrder
+
+	Cursor string
+	Limit  int
+}
+
+// LocationArea - прямоугольник на карте, границы включаются
+type LocationArea struct {
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
 
 type GetAllCouriersResponse struct {
 	Couriers []CourierResponse
+
+	// NextCursor - курсор следующей страницы, пустой на последней странице
+	NextCursor string
 }
 
 type CourierResponse struct {
-	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name     string
-	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name      string
+	Speed     int
+	Transport string
+	Status    string
+	Location  LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
 }
 
 type GetAllCouriersQueryHandler interface {
@@ -33,13 +69,91 @@ func NewGetAllCouriersQueryHandler(db *gorm.DB) (GetAllCouriersQueryHandler, err
 	return &getAllCouriersQueryHandler{db: db}, nil
 }
 
-func (q *getAllCouriersQueryHandler) Handle(_ GetAllCouriersQuery) (GetAllCouriersResponse, error) {
-	var couriers []CourierResponse
-	result := q.db.Raw("SELECT id, name, location_x, location_y FROM couriers").Scan(&couriers)
+func (q *getAllCouriersQueryHandler) Handle(query GetAllCouriersQuery) (GetAllCouriersResponse, error) {
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
+	order, err := sortOrder(query.SortOrder)
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
+	if query.SortBy == "" {
+		query.SortBy = CourierSortByID
+	}
+	sort := string(query.SortBy) + ":" + string(order)
+
+	cursor, err := decodePageCursor(query.Cursor, sort)
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
+
+	var (
+		column     string
+		afterValue any
+	)
+	switch query.SortBy {
+	case CourierSortByID:
+		column = "c.id"
+	case CourierSortByName:
+		column = "c.name"
+		if cursor != nil {
+			afterValue = cursor.Value
+		}
+	case CourierSortBySpeed:
+		column = "c.speed"
+		if cursor != nil {
+			if afterValue, err = strconv.Atoi(cursor.Value); err != nil {
+				return GetAllCouriersResponse{}, errs.NewValueIsInvalidErrorWithCause("cursor", err)
+			}
+		}
+	default:
+		return GetAllCouriersResponse{}, errs.NewValueIsInvalidError("sortBy")
+	}
+
+	tx := q.db.Table("couriers c").
+		Select("c.id, c.name, c.speed, c.transport_name AS transport, c.status, c.location_x, c.location_y").
+		Where("c.status <> ?", courier.StatusDeactivated)
+	if query.Busy != nil {
+		busy := `EXISTS (
+			SELECT 1 FROM storage_places sp
+			JOIN stored_orders so ON so.storage_place_id = sp.id
+			WHERE sp.courier_id = c.id)`
+		if *query.Busy {
+			tx = tx.Where(busy)
+		} else {
+			tx = tx.Where("NOT " + busy)
+		}
+	}
+	if query.MinSpeed != nil {
+		tx = tx.Where("c.speed >= ?", *query.MinSpeed)
+	}
+	if query.MaxSpeed != nil {
+		tx = tx.Where("c.speed <= ?", *query.MaxSpeed)
+	}
+	if area := query.Area; area != nil {
+		tx = tx.Where("c.location_x BETWEEN ? AND ? AND c.location_y BETWEEN ? AND ?",
+			area.MinX, area.MaxX, area.MinY, area.MaxY)
+	}
 
+	var couriers []CourierResponse
+	result := paginate(tx, column, "c.id", order, cursor, afterValue, limit).Scan(&couriers)
 	if result.Error != nil {
 		return GetAllCouriersResponse{}, result.Error
 	}
 
-	return GetAllCouriersResponse{Couriers: couriers}, nil
+	response := GetAllCouriersResponse{Couriers: couriers}
+	if len(couriers) > limit {
+		response.Couriers = couriers[:limit]
+		last := response.Couriers[limit-1]
+		next := pageCursor{Sort: sort, ID: last.ID}
+		switch query.SortBy {
+		case CourierSortByName:
+			next.Value = last.Name
+		case CourierSortBySpeed:
+			next.Value = strconv.Itoa(last.Speed)
+		}
+		response.NextCursor = next.encode()
+	}
+	return response, nil
 }
@@ -0,0 +1,164 @@
+package queries
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/core/domain/sevices"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type GetCourierQuery struct {
+	CourierID uuid.UUID
+}
+
+type GetCourierResponse struct {
+	ID            uuid.UUID `gorm:"type:uuid"`
+	Name          string
+	Speed         int
+	GeoSpeed      int
+	Transport     string
+	Capacity      int
+	Status        string
+	Location      LocationResponse       `gorm:"embedded;embeddedPrefix:location_"`
+	Zone          string                 `gorm:"column:location_zone"`
+	Coordinate    CoordinateResponse     `gorm:"embedded;embeddedPrefix:coordinate_"`
+	StoragePlaces []StoragePlaceResponse `gorm:"-"`
+	Orders        []CarriedOrderResponse `gorm:"-"`
+}
+
+type StoragePlaceResponse struct {
+	ID          uuid.UUID `gorm:"type:uuid"`
+	Name        string
+	TotalVolume int
+	UsedVolume  int
+	FreeVolume  int
+}
+
+// CarriedOrderResponse - заказ, который курьер везет, и сколько ему до него осталось.
+// Steps и ETA пустые, если до заказа нет пути - например, дорогу перекрыли, пока курьер ехал
+type CarriedOrderResponse struct {
+	ID             uuid.UUID `gorm:"type:uuid"`
+	StoragePlaceID uuid.UUID `gorm:"type:uuid"`
+	Volume         int
+	Location       LocationResponse   `gorm:"embedded;embeddedPrefix:location_"`
+	Zone           string             `gorm:"column:location_zone"`
+	Coordinate     CoordinateResponse `gorm:"embedded;embeddedPrefix:coordinate_"`
+	Steps          *float64           `gorm:"-"`
+	ETA            *time.Time         `gorm:"-"`
+}
+
+type GetCourierQueryHandler interface {
+	Handle(GetCourierQuery) (GetCourierResponse, error)
+}
+
+type getCourierQueryHandler struct {
+	db        *gorm.DB
+	zones     kernel.Zones
+	navigator services.Navigator
+}
+
+// NewGetCourierQueryHandler создает обработчик, который считает время в пути через navigator -
+// ту же модель перемещения, по которой курьеров назначают и двигают
+func NewGetCourierQueryHandler(db *gorm.DB, zones kernel.Zones, navigator services.Navigator) (GetCourierQueryHandler, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+	if zones.Primary().IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("zones")
+	}
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &getCourierQueryHandler{db: db, zones: zones, navigator: navigator}, nil
+}
+
+func (h *getCourierQueryHandler) Handle(query GetCourierQuery) (GetCourierResponse, error) {
+	if query.CourierID == uuid.Nil {
+		return GetCourierResponse{}, errs.NewValueIsRequiredError("courierID")
+	}
+
+	var couriers []GetCourierResponse
+	result := h.db.Raw(`
+		SELECT id, name, speed, transport_geo_speed AS geo_speed, transport_name AS transport,
+		       transport_capacity AS capacity, status, location_x, location_y, location_zone,
+		       coordinate_latitude, coordinate_longitude
+		FROM couriers
+		WHERE id = ?`, query.CourierID).Scan(&couriers)
+	if result.Error != nil {
+		return GetCourierResponse{}, result.Error
+	}
+	if len(couriers) == 0 {
+		return GetCourierResponse{}, errs.NewObjectNotFoundError("courier", query.CourierID)
+	}
+	response := couriers[0]
+
+	result = h.db.Raw(`
+		SELECT sp.id, sp.name, sp.total_volume,
+		       COALESCE(SUM(so.volume), 0) AS used_volume,
+		       sp.total_volume - COALESCE(SUM(so.volume), 0) AS free_volume
+		FROM storage_places sp
+		LEFT JOIN stored_orders so ON so.storage_place_id = sp.id
+		WHERE sp.courier_id = ?
+		GROUP BY sp.id, sp.name, sp.total_volume
+		ORDER BY sp.name`, query.CourierID).Scan(&response.StoragePlaces)
+	if result.Error != nil {
+		return GetCourierResponse{}, result.Error
+	}
+
+	result = h.db.Raw(`
+		SELECT o.id, so.storage_place_id, so.volume, o.location_x, o.location_y, o.location_zone,
+		       o.coordinate_latitude, o.coordinate_longitude
+		FROM storage_places sp
+		JOIN stored_orders so ON so.storage_place_id = sp.id
+		JOIN orders o ON o.id = so.order_id
+		WHERE sp.courier_id = ?`, query.CourierID).Scan(&response.Orders)
+	if result.Error != nil {
+		return GetCourierResponse{}, result.Error
+	}
+
+	// Время в пути считает навигатор, чтобы оценка совпадала с той, по которой курьера назначали
+	location, err := h.zones.NewLocation(response.Zone, response.Location.X, response.Location.Y)
+	if err != nil {
+		return GetCourierResponse{}, err
+	}
+	coordinate, err := response.Coordinate.toDomain()
+	if err != nil {
+		return GetCourierResponse{}, err
+	}
+	transport := courier.RestoreTransport(response.Transport, response.Speed, response.GeoSpeed, response.Capacity)
+	courierAggregate := courier.RestoreCourier(response.ID, response.Name, transport, location,
+		coordinate, courier.Status(response.Status), nil)
+	now := time.Now().UTC()
+	for i, o := range response.Orders {
+		target, err := h.zones.NewLocation(o.Zone, o.Location.X, o.Location.Y)
+		if err != nil {
+			return GetCourierResponse{}, err
+		}
+		targetCoordinate, err := o.Coordinate.toDomain()
+		if err != nil {
+			return GetCourierResponse{}, err
+		}
+		carriedOrder := order.RestoreOrder(o.ID, &response.ID, target, targetCoordinate, o.Volume,
+			order.StatusAssigned, "", time.Time{}, kernel.Address{}, nil, order.DeliveryWindow{}, false)
+		steps, err := h.navigator.StepsTo(courierAggregate, carriedOrder)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return GetCourierResponse{}, err
+		}
+		eta, err := h.navigator.ArrivalTime(courierAggregate, carriedOrder, now)
+		if err != nil {
+			return GetCourierResponse{}, err
+		}
+		response.Orders[i].Steps = &steps
+		response.Orders[i].ETA = &eta
+	}
+
+	return response, nil
+}
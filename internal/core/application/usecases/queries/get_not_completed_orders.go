@@ -5,17 +5,49 @@ import (
 	"delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"strconv"
+	"time"
 )
 
-type GetNotCompletedOrdersQuery struct{}
+type OrderSortField string
+
+const (
+	OrderSortByID        OrderSortField = "id"
+	OrderSortByCreatedAt OrderSortField = "createdAt"
+	OrderSortByVolume    OrderSortField = "volume"
+	OrderSortByStatus    OrderSortField = "status"
+)
+
+type GetNotCompletedOrdersQuery struct {
+	// Фильтры, nil - без ограничения. Завершенные и отмененные заказы не попадают в выдачу в любом случае
+	Status        *order.Status
+	CourierID     *uuid.UUID
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	SortBy    OrderSortField
+	SortOrder SortOrder
+
+	Cursor string
+	Limit  int
+}
 
 type GetNotCompletedOrdersResponse struct {
 	Orders []OrderResponse
+
+	// NextCursor - курсор следующей страницы, пустой на последней странице
+	NextCursor string
 }
 
 type OrderResponse struct {
-	ID       uuid.UUID        `gorm:"type:uuid;primaryKey"`
-	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Status    string
+	CourierID *uuid.UUID `gorm:"type:uuid"`
+	Volume    int
+	CreatedAt time.Time
+	Location  LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	Address   AddressResponse  `gorm:"embedded;embeddedPrefix:address_"`
+	Items     []ItemResponse   `gorm:"-"`
 }
 
 func (OrderResponse) TableName() string {
@@ -37,17 +69,111 @@ func NewGetNotCompletedOrdersQueryHandler(db *gorm.DB) (GetNotCompletedOrdersQue
 	return &getNotCompletedOrdersQueryHandler{db: db}, nil
 }
 
-func (h *getNotCompletedOrdersQueryHandler) Handle(_ GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error) {
-	var orders []OrderResponse
+func (h *getNotCompletedOrdersQueryHandler) Handle(query GetNotCompletedOrdersQuery) (GetNotCompletedOrdersResponse, error) {
+	limit, err := pageLimit(query.Limit)
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
+	sortDirection, err := sortOrder(query.SortOrder)
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
+	if query.Status != nil && *query.Status != order.StatusCreated && *query.Status != order.StatusAssigned {
+		return GetNotCompletedOrdersResponse{}, errs.NewValueIsInvalidError("status")
+	}
+	if query.SortBy == "" {
+		query.SortBy = OrderSortByID
+	}
+	sort := string(query.SortBy) + ":" + string(sortDirection)
 
-	result := h.db.Raw(`
-		SELECT id, location_x, location_y 
-		FROM orders 
-		WHERE status != ?`, order.StatusCompleted).Scan(&orders)
+	cursor, err := decodePageCursor(query.Cursor, sort)
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
 
+	var (
+		column     string
+		afterValue any
+	)
+	switch query.SortBy {
+	case OrderSortByID:
+		column = "o.id"
+	case OrderSortByCreatedAt:
+		column = "o.created_at"
+		if cursor != nil {
+			if afterValue, err = time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+				return GetNotCompletedOrdersResponse{}, errs.NewValueIsInvalidErrorWithCause("cursor", err)
+			}
+		}
+	case OrderSortByVolume:
+		column = "o.volume"
+		if cursor != nil {
+			if afterValue, err = strconv.Atoi(cursor.Value); err != nil {
+				return GetNotCompletedOrdersResponse{}, errs.NewValueIsInvalidErrorWithCause("cursor", err)
+			}
+		}
+	case OrderSortByStatus:
+		column = "o.status"
+		if cursor != nil {
+			afterValue = cursor.Value
+		}
+	default:
+		return GetNotCompletedOrdersResponse{}, errs.NewValueIsInvalidError("sortBy")
+	}
+
+	tx := h.db.Table("orders o").
+		Select(`o.id, o.status, o.courier_id, o.volume, o.created_at, o.location_x, o.location_y,
+		       COALESCE(a.country, '') AS address_country, COALESCE(a.city, '') AS address_city,
+		       COALESCE(a.street, '') AS address_street, COALESCE(a.house, '') AS address_house,
+		       COALESCE(a.apartment, '') AS address_apartment`).
+		Joins("LEFT JOIN order_addresses a ON a.order_id = o.id").
+		Where("o.status NOT IN (?, ?)", order.StatusCompleted, order.StatusCancelled)
+	if query.Status != nil {
+		tx = tx.Where("o.status = ?", *query.Status)
+	}
+	if query.CourierID != nil {
+		tx = tx.Where("o.courier_id = ?", *query.CourierID)
+	}
+	if query.CreatedAfter != nil {
+		tx = tx.Where("o.created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		tx = tx.Where("o.created_at < ?", *query.CreatedBefore)
+	}
+
+	var orders []OrderResponse
+	result := paginate(tx, column, "o.id", sortDirection, cursor, afterValue, limit).Scan(&orders)
 	if result.Error != nil {
 		return GetNotCompletedOrdersResponse{}, result.Error
 	}
 
-	return GetNotCompletedOrdersResponse{Orders: orders}, nil
+	response := GetNotCompletedOrdersResponse{Orders: orders}
+	if len(orders) > limit {
+		response.Orders = orders[:limit]
+		last := response.Orders[limit-1]
+		next := pageCursor{Sort: sort, ID: last.ID}
+		switch query.SortBy {
+		case OrderSortByCreatedAt:
+			next.Value = last.CreatedAt.Format(time.RFC3339Nano)
+		case OrderSortByVolume:
+			next.Value = strconv.Itoa(last.Volume)
+		case OrderSortByStatus:
+			next.Value = last.Status
+		}
+		response.NextCursor = next.encode()
+	}
+
+	orderIDs := make([]uuid.UUID, len(response.Orders))
+	for i, o := range response.Orders {
+		orderIDs[i] = o.ID
+	}
+	itemsByOrder, err := getItemsByOrderIDs(h.db, orderIDs)
+	if err != nil {
+		return GetNotCompletedOrdersResponse{}, err
+	}
+	for i := range response.Orders {
+		response.Orders[i].Items = itemsByOrder[response.Orders[i].ID]
+	}
+
+	return response, nil
 }
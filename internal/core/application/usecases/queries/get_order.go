@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type GetOrderQuery struct {
+	OrderID uuid.UUID
+}
+
+type GetOrderResponse struct {
+	ID             uuid.UUID `gorm:"type:uuid"`
+	Status         string
+	CourierID      *uuid.UUID `gorm:"type:uuid"`
+	Volume         int
+	CancelReason   string
+	Late           bool
+	Location       LocationResponse        `gorm:"embedded;embeddedPrefix:location_"`
+	Address        AddressResponse         `gorm:"embedded;embeddedPrefix:address_"`
+	DeliveryWindow DeliveryWindowResponse  `gorm:"embedded;embeddedPrefix:delivery_window_"`
+	Items          []ItemResponse          `gorm:"-"`
+	History        []StatusHistoryResponse `gorm:"-"`
+}
+
+type DeliveryWindowResponse struct {
+	From *time.Time
+	To   *time.Time
+}
+
+type StatusHistoryResponse struct {
+	Status    string
+	ChangedAt time.Time
+}
+
+type GetOrderQueryHandler interface {
+	Handle(GetOrderQuery) (GetOrderResponse, error)
+}
+
+type getOrderQueryHandler struct {
+	db *gorm.DB
+}
+
+func NewGetOrderQueryHandler(db *gorm.DB) (GetOrderQueryHandler, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("db")
+	}
+	return &getOrderQueryHandler{db: db}, nil
+}
+
+func (h *getOrderQueryHandler) Handle(query GetOrderQuery) (GetOrderResponse, error) {
+	if query.OrderID == uuid.Nil {
+		return GetOrderResponse{}, errs.NewValueIsRequiredError("orderID")
+	}
+
+	var orders []GetOrderResponse
+	result := h.db.Raw(`
+		SELECT o.id, o.status, o.courier_id, o.volume, o.cancel_reason, o.late,
+		       o.location_x, o.location_y, o.delivery_window_from, o.delivery_window_to,
+		       COALESCE(a.country, '') AS address_country, COALESCE(a.city, '') AS address_city,
+		       COALESCE(a.street, '') AS address_street, COALESCE(a.house, '') AS address_house,
+		       COALESCE(a.apartment, '') AS address_apartment
+		FROM orders o
+		LEFT JOIN order_addresses a ON a.order_id = o.id
+		WHERE o.id = ?`, query.OrderID).Scan(&orders)
+	if result.Error != nil {
+		return GetOrderResponse{}, result.Error
+	}
+	if len(orders) == 0 {
+		return GetOrderResponse{}, errs.NewObjectNotFoundError("order", query.OrderID)
+	}
+	response := orders[0]
+
+	itemsByOrder, err := getItemsByOrderIDs(h.db, []uuid.UUID{response.ID})
+	if err != nil {
+		return GetOrderResponse{}, err
+	}
+	response.Items = itemsByOrder[response.ID]
+
+	result = h.db.Raw(`
+		SELECT status, changed_at
+		FROM order_status_history
+		WHERE order_id = ?
+		ORDER BY changed_at`, query.OrderID).Scan(&response.History)
+	if result.Error != nil {
+		return GetOrderResponse{}, result.Error
+	}
+
+	return response, nil
+}
@@ -0,0 +1,38 @@
+package queries
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ItemResponse struct {
+	OrderID  uuid.UUID `gorm:"type:uuid"`
+	ID       uuid.UUID `gorm:"type:uuid"`
+	GoodID   uuid.UUID `gorm:"type:uuid"`
+	Title    string
+	Price    float64
+	Quantity int
+}
+
+// getItemsByOrderIDs читает товары нескольких заказов одним запросом и группирует их по заказам
+func getItemsByOrderIDs(db *gorm.DB, orderIDs []uuid.UUID) (map[uuid.UUID][]ItemResponse, error) {
+	itemsByOrder := make(map[uuid.UUID][]ItemResponse)
+	if len(orderIDs) == 0 {
+		return itemsByOrder, nil
+	}
+
+	var items []ItemResponse
+	result := db.Raw(`
+		SELECT order_id, id, good_id, title, price, quantity
+		FROM order_items
+		WHERE order_id IN ?
+		ORDER BY title`, orderIDs).Scan(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+	return itemsByOrder, nil
+}
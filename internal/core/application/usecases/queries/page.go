@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"delivery/internal/pkg/errs"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// pageCursor - позиция, после которой продолжается выдача: сортировка, значение поля сортировки
+// и id последней отданной записи. Клиенту уходит непрозрачной строкой
+type pageCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func (c pageCursor) encode() string {
+	bytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// decodePageCursor разбирает курсор. Курсор, выданный для другой сортировки, не подходит
+func decodePageCursor(cursor string, sort string) (*pageCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("cursor", err)
+	}
+	var decoded pageCursor
+	if err := json.Unmarshal(bytes, &decoded); err != nil {
+		return nil, errs.NewValueIsInvalidErrorWithCause("cursor", err)
+	}
+	if decoded.Sort != sort || decoded.ID == uuid.Nil {
+		return nil, errs.NewValueIsInvalidError("cursor")
+	}
+	return &decoded, nil
+}
+
+func pageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultPageLimit, nil
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return 0, errs.NewValueIsOutOfRangeError("limit", limit, 1, MaxPageLimit)
+	}
+	return limit, nil
+}
+
+func sortOrder(order SortOrder) (SortOrder, error) {
+	switch order {
+	case "":
+		return SortOrderAsc, nil
+	case SortOrderAsc, SortOrderDesc:
+		return order, nil
+	default:
+		return "", errs.NewValueIsInvalidError("sortOrder")
+	}
+}
+
+// paginate сортирует выборку по column, а при равенстве - по idColumn, продолжает ее после курсора
+// и ограничивает размер страницы. Одна лишняя запись нужна, чтобы понять, есть ли следующая страница
+func paginate(tx *gorm.DB, column string, idColumn string, order SortOrder,
+	after *pageCursor, afterValue any, limit int) *gorm.DB {
+	direction, operator := "ASC", ">"
+	if order == SortOrderDesc {
+		direction, operator = "DESC", "<"
+	}
+
+	if column == idColumn {
+		if after != nil {
+			tx = tx.Where(fmt.Sprintf("%s %s ?", idColumn, operator), after.ID)
+		}
+		return tx.Order(fmt.Sprintf("%s %s", idColumn, direction)).Limit(limit + 1)
+	}
+
+	if after != nil {
+		tx = tx.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, operator), afterValue, after.ID)
+	}
+	return tx.Order(fmt.Sprintf("%s %s, %s %s", column, direction, idColumn, direction)).Limit(limit + 1)
+}
@@ -7,63 +7,198 @@ import (
 	"delivery/internal/pkg/errs"
 	"errors"
 	"github.com/google/uuid"
-	"math/rand"
+	"math"
+	"time"
+)
+
+// StepDuration - время одного шага курьера: задача перемещения курьеров запускается раз в 10 секунд
+const StepDuration = 10 * time.Second
+
+// Сумка, которую получает курьер без явно заданных мест хранения
+const (
+	DefaultBagName      = "bag"
+	MinDefaultBagVolume = 10
+	MaxDefaultBagVolume = 40
 )
 
 var (
 	ErrNoFreeStoragePlace = errors.New("no free storage place")
+	ErrCourierNotOnShift  = errors.New("courier is not on shift")
+	ErrCourierHasOrders   = errors.New("courier still has orders")
+	ErrCourierDeactivated = errors.New("courier is deactivated")
+	ErrNoCoordinate       = errors.New("courier has no geo coordinate")
+
+	ErrTransportCapacityExceeded = errors.New("storage places do not fit the courier's transport")
+
+	ErrStoragePlaceNotFound = errors.New("storage place not found")
+	ErrStoragePlaceNotEmpty = errors.New("storage place still holds orders")
 )
 
 type Courier struct {
 	*ddd.BaseAggregate[uuid.UUID]
 	name             string
-	speed            int
+	transport        Transport
 	location         kernel.Location
+	coordinate       kernel.GeoCoordinate
+	status           Status
 	storagePlaceList []*StoragePlace
 }
 
-func NewCourier(name string, speed int, location kernel.Location) (*Courier, error) {
+func NewCourier(name string, transport Transport, location kernel.Location) (*Courier, error) {
 	if name == "" {
 		return nil, errs.NewValueIsRequiredError("name")
 	}
-	if speed <= 0 {
-		return nil, errs.NewValueIsRequiredError("speed")
+	if transport.IsEmpty() {
+		return nil, errs.NewValueIsRequiredError("transport")
 	}
 	if err := location.IsValid(); err != nil {
 		return nil, err
 	}
 
-	courier := &Courier{
+	return &Courier{
 		BaseAggregate:    ddd.NewBaseAggregate(uuid.New()),
 		name:             name,
-		speed:            speed,
+		transport:        transport,
 		location:         location,
+		status:           StatusOffDuty,
 		storagePlaceList: make([]*StoragePlace, 0),
-	}
-
-	capacity := rand.Intn(31) + 10
-	err := courier.AddStoragePlace("bag", capacity)
-	if err != nil {
-		return nil, err
-	}
-
-	return courier, nil
+	}, nil
 }
 
 func (c *Courier) AddStoragePlace(name string, totalVolume int) error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
 	storagePlace, err := NewStoragePlace(name, totalVolume)
 	if err != nil {
 		return err
 	}
+	if c.totalVolume()+totalVolume > c.transport.Capacity() {
+		return ErrTransportCapacityExceeded
+	}
 	c.storagePlaceList = append(c.storagePlaceList, storagePlace)
 	return nil
 
 }
 
+// AddDefaultBag выдает курьеру сумку случайного объема - так снаряжается курьер,
+// для которого места хранения не указаны явно. Сумка не бывает больше, чем помещается на транспорт
+func (c *Courier) AddDefaultBag(source kernel.RandomSource) error {
+	if source == nil {
+		return errs.NewValueIsRequiredError("source")
+	}
+	volume := source.Intn(MaxDefaultBagVolume-MinDefaultBagVolume+1) + MinDefaultBagVolume
+	volume = min(volume, c.transport.Capacity()-c.totalVolume())
+	return c.AddStoragePlace(DefaultBagName, volume)
+}
+
+// RemoveStoragePlace убирает место хранения. Место, в котором лежит заказ, убрать нельзя
+func (c *Courier) RemoveStoragePlace(storagePlaceID uuid.UUID) error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
+	for i, storagePlace := range c.storagePlaceList {
+		if storagePlace.ID() != storagePlaceID {
+			continue
+		}
+		if !storagePlace.IsEmpty() {
+			return ErrStoragePlaceNotEmpty
+		}
+		c.storagePlaceList = append(c.storagePlaceList[:i], c.storagePlaceList[i+1:]...)
+		return nil
+	}
+	return ErrStoragePlaceNotFound
+}
+
+func (c *Courier) Rename(name string) error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
+	if name == "" {
+		return errs.NewValueIsRequiredError("name")
+	}
+	c.name = name
+	return nil
+}
+
+// ChangeTransport пересаживает курьера на другой транспорт. Все его места хранения должны на нем поместиться
+func (c *Courier) ChangeTransport(transport Transport) error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
+	if transport.IsEmpty() {
+		return errs.NewValueIsRequiredError("transport")
+	}
+	if c.totalVolume() > transport.Capacity() {
+		return ErrTransportCapacityExceeded
+	}
+	c.transport = transport
+	return nil
+}
+
+// Deactivate выводит курьера из работы насовсем. Сначала он должен развезти все заказы
+func (c *Courier) Deactivate() error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
+	if c.HasOrders() {
+		return ErrCourierHasOrders
+	}
+	c.status = StatusDeactivated
+	return nil
+}
+
+// StartShift выводит курьера на смену, после чего ему можно назначать заказы
+func (c *Courier) StartShift() error {
+	if c.status == StatusDeactivated {
+		return ErrCourierDeactivated
+	}
+	if c.status != StatusOffDuty {
+		return errors.New("only off duty courier can start a shift")
+	}
+	c.status = StatusOnShift
+	return nil
+}
+
+// EndShift снимает курьера со смены. Курьер с заказами закончить смену не может
+func (c *Courier) EndShift() error {
+	if c.status != StatusOnShift && c.status != StatusOnBreak {
+		return errors.New("courier is not on shift")
+	}
+	if c.HasOrders() {
+		return ErrCourierHasOrders
+	}
+	c.status = StatusOffDuty
+	return nil
+}
+
+// StartBreak ставит курьера на перерыв: новые заказы он не получает и никуда не едет
+func (c *Courier) StartBreak() error {
+	if c.status != StatusOnShift {
+		return ErrCourierNotOnShift
+	}
+	c.status = StatusOnBreak
+	return nil
+}
+
+func (c *Courier) EndBreak() error {
+	if c.status != StatusOnBreak {
+		return errors.New("courier is not on break")
+	}
+	c.status = StatusOnShift
+	return nil
+}
+
 func (c *Courier) CanTakeOrder(order *order.Order) (bool, error) {
 	if order == nil {
 		return false, errs.NewValueIsRequiredError("order")
 	}
+	if c.status != StatusOnShift {
+		return false, nil
+	}
+	if !c.transport.CanCarry(order.Volume()) {
+		return false, nil
+	}
 
 	for _, storagePlace := range c.storagePlaceList {
 		canStore := storagePlace.CanStore(order.Volume())
@@ -78,6 +213,12 @@ func (c *Courier) TakeOrder(order *order.Order) error {
 	if order == nil {
 		return errs.NewValueIsRequiredError("order")
 	}
+	if c.status != StatusOnShift {
+		return ErrCourierNotOnShift
+	}
+	if !c.transport.CanCarry(order.Volume()) {
+		return ErrTransportCapacityExceeded
+	}
 	for _, storagePlace := range c.storagePlaceList {
 		if storagePlace.CanStore(order.Volume()) {
 			return storagePlace.StoreOrder(order.Id(), order.Volume())
@@ -90,15 +231,92 @@ func (c *Courier) CompleteOrder(order *order.Order) error {
 	if order == nil {
 		return errs.NewValueIsRequiredError("order")
 	}
+	return c.removeOrder(order)
+}
+
+// ReleaseOrder освобождает место, занятое отмененным заказом
+func (c *Courier) ReleaseOrder(order *order.Order) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+	return c.removeOrder(order)
+}
+
+func (c *Courier) removeOrder(order *order.Order) error {
 	for _, storagePlace := range c.storagePlaceList {
-		if storagePlace.OrderID() != nil && *storagePlace.OrderID() == order.Id() {
-			storagePlace.RemoveOrder()
-			return nil
+		if storagePlace.Contains(order.Id()) {
+			return storagePlace.RemoveOrder(order.Id())
 		}
 	}
 	return errs.NewObjectNotFoundError("order", order.Id())
 }
 
+func (c *Courier) OrderIDs() []uuid.UUID {
+	orderIDs := make([]uuid.UUID, 0)
+	for _, storagePlace := range c.storagePlaceList {
+		orderIDs = append(orderIDs, storagePlace.OrderIDs()...)
+	}
+	return orderIDs
+}
+
+func (c *Courier) HasOrders() bool {
+	return len(c.OrderIDs()) > 0
+}
+
+// Load возвращает долю занятого объема во всех местах хранения курьера: от 0 до 1
+func (c *Courier) Load() float64 {
+	totalVolume, usedVolume := 0, 0
+	for _, storagePlace := range c.storagePlaceList {
+		totalVolume += storagePlace.TotalVolume()
+		usedVolume += storagePlace.UsedVolume()
+	}
+	if totalVolume == 0 {
+		return 0
+	}
+	return float64(usedVolume) / float64(totalVolume)
+}
+
+func (c *Courier) totalVolume() int {
+	totalVolume := 0
+	for _, storagePlace := range c.storagePlaceList {
+		totalVolume += storagePlace.TotalVolume()
+	}
+	return totalVolume
+}
+
+// NearestOrder выбирает из заказов курьера тот, до которого ему быстрее всего добраться.
+// Заказы, к которым нет пути, пропускаются; если недоступны все - kernel.ErrRouteNotFound
+func (c *Courier) NearestOrder(orders []*order.Order) (*order.Order, error) {
+	if len(orders) == 0 {
+		return nil, errs.NewValueIsRequiredError("orders")
+	}
+
+	var (
+		nearest *order.Order
+		minTime = math.MaxFloat64
+	)
+	for _, o := range orders {
+		if o == nil {
+			return nil, errs.NewValueIsRequiredError("order")
+		}
+		time, err := c.StepsTo(o.Location())
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if time < minTime {
+			minTime = time
+			nearest = o
+		}
+	}
+	if nearest == nil {
+		return nil, kernel.ErrRouteNotFound
+	}
+	return nearest, nil
+}
+
 func (c *Courier) StepsTo(target kernel.Location) (float64, error) {
 	if err := target.IsValid(); err != nil {
 		return 0, err
@@ -108,32 +326,79 @@ func (c *Courier) StepsTo(target kernel.Location) (float64, error) {
 		return 0, err
 	}
 
-	time := float64(distance) / float64(c.speed)
+	time := float64(distance) / float64(c.transport.Speed())
 	return time, err
 
 }
 
+// ArrivalTime - момент, когда курьер, выехав в now, доберется до target
+func (c *Courier) ArrivalTime(target kernel.Location, now time.Time) (time.Time, error) {
+	steps, err := c.StepsTo(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(time.Duration(math.Ceil(steps)) * StepDuration), nil
+}
+
+// StepTowards - один шаг курьера: он проезжает по кратчайшему пути к target до speed клеток.
+// Если до target ближе, курьер останавливается в нем, не дожидаясь конца шага
 func (c *Courier) StepTowards(target kernel.Location) error {
 	if err := target.IsValid(); err != nil {
 		return err
 	}
-	newX, newY := c.location.X(), c.location.Y()
+	route, err := c.location.RouteTo(target)
+	if err != nil {
+		return err
+	}
+	if len(route) == 0 {
+		return nil
+	}
+	cells := min(c.transport.Speed(), len(route))
+	c.location = route[cells-1]
+	return nil
+}
 
-	if newX < target.X() {
-		newX++
-	} else if newX > target.X() {
-		newX--
-	} else if newY < target.Y() {
-		newY++
-	} else if newY > target.Y() {
-		newY--
+// SetCoordinate ставит курьера в точку с географическими координатами
+func (c *Courier) SetCoordinate(coordinate kernel.GeoCoordinate) error {
+	if coordinate.IsEmpty() {
+		return errs.NewValueIsRequiredError("coordinate")
 	}
+	c.coordinate = coordinate
+	return nil
+}
 
-	newLoc, err := kernel.NewLocation(newX, newY)
+// MoveTo переносит курьера в клетку сетки. Нужен, когда курьер перемещается по координатам
+// и сетка только повторяет его положение
+func (c *Courier) MoveTo(location kernel.Location) error {
+	if err := location.IsValid(); err != nil {
+		return err
+	}
+	c.location = location
+	return nil
+}
+
+// GeoStepsTo - сколько шагов курьеру ехать до target по координатам со скоростью его транспорта в км/ч
+func (c *Courier) GeoStepsTo(target kernel.GeoCoordinate) (float64, error) {
+	if c.coordinate.IsEmpty() {
+		return 0, ErrNoCoordinate
+	}
+	distance, err := c.coordinate.DistanceTo(target)
+	if err != nil {
+		return 0, err
+	}
+	return distance / c.transport.MetresPerStep(), nil
+}
+
+// StepTowardsCoordinate сдвигает курьера к target по прямой на расстояние, которое транспорт проезжает за шаг
+func (c *Courier) StepTowardsCoordinate(target kernel.GeoCoordinate) error {
+	if c.coordinate.IsEmpty() {
+		return ErrNoCoordinate
+	}
+	coordinate, err := c.coordinate.MoveTowards(target, c.transport.MetresPerStep())
 	if err != nil {
 		return err
 	}
-	c.location = newLoc
+	c.coordinate = coordinate
 	return nil
 }
 
@@ -153,13 +418,26 @@ func (c *Courier) Name() string {
 }
 
 func (c *Courier) Speed() int {
-	return c.speed
+	return c.transport.Speed()
+}
+
+func (c *Courier) Transport() Transport {
+	return c.transport
 }
 
 func (c *Courier) Location() kernel.Location {
 	return c.location
 }
 
+// Coordinate - географические координаты курьера, пустые, если курьер ездит только по сетке
+func (c *Courier) Coordinate() kernel.GeoCoordinate {
+	return c.coordinate
+}
+
+func (c *Courier) Status() Status {
+	return c.status
+}
+
 func (c *Courier) StoragePlaces() []StoragePlace {
 	res := make([]StoragePlace, len(c.storagePlaceList))
 	for i, storagePlace := range c.storagePlaceList {
@@ -168,12 +446,15 @@ func (c *Courier) StoragePlaces() []StoragePlace {
 	return res
 }
 
-func RestoreCourier(id uuid.UUID, name string, speed int, location kernel.Location, storagePlaces []*StoragePlace) *Courier {
+func RestoreCourier(id uuid.UUID, name string, transport Transport, location kernel.Location, coordinate kernel.GeoCoordinate,
+	status Status, storagePlaces []*StoragePlace) *Courier {
 	return &Courier{
 		BaseAggregate:    ddd.NewBaseAggregate(id),
 		name:             name,
-		speed:            speed,
+		transport:        transport,
 		location:         location,
+		coordinate:       coordinate,
+		status:           status,
 		storagePlaceList: storagePlaces,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func validLocation() kernel.Location {
@@ -20,7 +21,7 @@ func targetLocation() kernel.Location {
 }
 
 func Test_NewCourier_Success(t *testing.T) {
-	c, err := courier.NewCourier("CourierName", 3, validLocation())
+	c, err := courier.NewCourier("CourierName", courier.Car, validLocation())
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 	assert.Equal(t, "CourierName", c.Name())
@@ -28,26 +29,54 @@ func Test_NewCourier_Success(t *testing.T) {
 }
 
 func Test_NewCourier_EmptyName(t *testing.T) {
-	c, err := courier.NewCourier("", 3, validLocation())
+	c, err := courier.NewCourier("", courier.Car, validLocation())
 	assert.Error(t, err)
 	assert.Nil(t, c)
 }
 
-func Test_NewCourier_ZeroSpeed(t *testing.T) {
-	c, err := courier.NewCourier("Courier", 0, validLocation())
+func Test_NewCourier_EmptyTransport(t *testing.T) {
+	c, err := courier.NewCourier("Courier", courier.Transport{}, validLocation())
 	assert.Error(t, err)
 	assert.Nil(t, c)
 }
 
+// fixedRandomSource всегда возвращает одно и то же число, ограниченное n
+type fixedRandomSource int
+
+func (s fixedRandomSource) Intn(n int) int {
+	return min(int(s), n-1)
+}
+
+func Test_NewCourier_NoStoragePlaces(t *testing.T) {
+	c, err := courier.NewCourier("CourierName", courier.Car, validLocation())
+	assert.NoError(t, err)
+	assert.Empty(t, c.StoragePlaces())
+}
+
+func Test_AddDefaultBag(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+
+	err := c.AddDefaultBag(fixedRandomSource(5))
+	assert.NoError(t, err)
+	assert.Len(t, c.StoragePlaces(), 1)
+	assert.Equal(t, courier.DefaultBagName, c.StoragePlaces()[0].Name())
+	assert.Equal(t, courier.MinDefaultBagVolume+5, c.StoragePlaces()[0].TotalVolume())
+
+	err = c.AddDefaultBag(fixedRandomSource(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, courier.MaxDefaultBagVolume, c.StoragePlaces()[1].TotalVolume())
+}
+
 func Test_AddStoragePlace_Success(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
 	err := c.AddStoragePlace("MainStorage", 10)
 	assert.NoError(t, err)
 	assert.Len(t, c.StoragePlaces(), 1)
 }
 
 func Test_CanTakeOrder_Success(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
 	_ = c.AddStoragePlace("MainStorage", 10)
 
 	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
@@ -58,7 +87,8 @@ func Test_CanTakeOrder_Success(t *testing.T) {
 }
 
 func Test_CanTakeOrder_NoFreeSpace(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
 	_ = c.AddStoragePlace("SmallStorage", 3) // маленький объём
 
 	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5) // заказ больше объёма
@@ -69,7 +99,8 @@ func Test_CanTakeOrder_NoFreeSpace(t *testing.T) {
 }
 
 func Test_TakeOrder_Success(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
 	_ = c.AddStoragePlace("Storage", 10)
 
 	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
@@ -79,7 +110,8 @@ func Test_TakeOrder_Success(t *testing.T) {
 }
 
 func Test_TakeOrder_NoStoragePlace(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
 	_ = c.AddStoragePlace("SmallStorage", 3)
 
 	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
@@ -89,7 +121,8 @@ func Test_TakeOrder_NoStoragePlace(t *testing.T) {
 }
 
 func Test_CompleteOrder_Success(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
 	_ = c.AddStoragePlace("Storage", 10)
 
 	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
@@ -99,16 +132,130 @@ func Test_CompleteOrder_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_ReleaseOrder_Success(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Storage", 10)
+
+	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
+	_ = c.TakeOrder(o)
+
+	err := c.ReleaseOrder(o)
+	assert.NoError(t, err)
+	assert.False(t, c.HasOrders())
+}
+
+func Test_TakeOrder_SeveralOrders(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Trunk", 100)
+
+	o1, _ := order.NewOrder(uuid.New(), targetLocation(), 45)
+	o2, _ := order.NewOrder(uuid.New(), targetLocation(), 45)
+	o3, _ := order.NewOrder(uuid.New(), targetLocation(), 45)
+
+	assert.NoError(t, c.TakeOrder(o1))
+	assert.NoError(t, c.TakeOrder(o2))
+	assert.ErrorIs(t, c.TakeOrder(o3), courier.ErrNoFreeStoragePlace)
+
+	assert.True(t, c.HasOrders())
+	assert.ElementsMatch(t, []uuid.UUID{o1.Id(), o2.Id()}, c.OrderIDs())
+
+	err := c.CompleteOrder(o1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{o2.Id()}, c.OrderIDs())
+}
+
+func Test_NearestOrder(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Pedestrian, validLocation())
+	_ = c.StartShift()
+
+	nearLocation, _ := kernel.NewLocation(2, 2)
+	farOrder, _ := order.NewOrder(uuid.New(), targetLocation(), 1)
+	nearOrder, _ := order.NewOrder(uuid.New(), nearLocation, 1)
+
+	nearest, err := c.NearestOrder([]*order.Order{farOrder, nearOrder})
+	assert.NoError(t, err)
+	assert.Equal(t, nearOrder, nearest)
+}
+
+func Test_NearestOrder_NoOrders(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Pedestrian, validLocation())
+
+	nearest, err := c.NearestOrder(nil)
+	assert.Error(t, err)
+	assert.Nil(t, nearest)
+}
+
+func Test_Shift_Lifecycle(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	assert.Equal(t, courier.StatusOffDuty, c.Status())
+
+	assert.NoError(t, c.StartShift())
+	assert.Equal(t, courier.StatusOnShift, c.Status())
+	assert.Error(t, c.StartShift())
+
+	assert.NoError(t, c.StartBreak())
+	assert.Equal(t, courier.StatusOnBreak, c.Status())
+	assert.NoError(t, c.EndBreak())
+	assert.Equal(t, courier.StatusOnShift, c.Status())
+
+	assert.NoError(t, c.EndShift())
+	assert.Equal(t, courier.StatusOffDuty, c.Status())
+	assert.Error(t, c.EndShift())
+	assert.ErrorIs(t, c.StartBreak(), courier.ErrCourierNotOnShift)
+}
+
+func Test_EndShift_WithOrders(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Storage", 10)
+
+	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
+	_ = c.TakeOrder(o)
+
+	err := c.EndShift()
+	assert.ErrorIs(t, err, courier.ErrCourierHasOrders)
+	assert.Equal(t, courier.StatusOnShift, c.Status())
+}
+
+func Test_CanTakeOrder_NotOnShift(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.AddStoragePlace("Storage", 10)
+
+	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
+
+	canTake, err := c.CanTakeOrder(o)
+	assert.NoError(t, err)
+	assert.False(t, canTake)
+	assert.ErrorIs(t, c.TakeOrder(o), courier.ErrCourierNotOnShift)
+
+	_ = c.StartShift()
+	_ = c.StartBreak()
+	canTake, err = c.CanTakeOrder(o)
+	assert.NoError(t, err)
+	assert.False(t, canTake)
+}
+
 func Test_StepsTo(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
 
 	steps, err := c.StepsTo(targetLocation())
 	assert.NoError(t, err)
 	assert.Equal(t, 2.0, steps)
 }
 
+func Test_ArrivalTime(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	arrival, err := c.ArrivalTime(targetLocation(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(2*courier.StepDuration), arrival)
+}
+
 func Test_StepTowards(t *testing.T) {
-	c, _ := courier.NewCourier("CourierName", 3, validLocation())
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
 
 	err := c.StepTowards(targetLocation())
 	assert.NoError(t, err)
@@ -118,9 +265,192 @@ func Test_StepTowards(t *testing.T) {
 
 func Test_Courier_Equal(t *testing.T) {
 	loc := validLocation()
-	c1, _ := courier.NewCourier("Courier1", 3, loc)
-	c2, _ := courier.NewCourier("Courier2", 3, loc)
+	c1, _ := courier.NewCourier("Courier1", courier.Car, loc)
+	c2, _ := courier.NewCourier("Courier2", courier.Car, loc)
 	assert.True(t, c1.Equal(c1))
 	assert.False(t, c1.Equal(c2))
 	assert.False(t, c1.Equal(nil))
 }
+
+func Test_RenameAndChangeTransport(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+
+	assert.NoError(t, c.Rename("NewName"))
+	assert.Equal(t, "NewName", c.Name())
+	assert.Error(t, c.Rename(""))
+
+	assert.NoError(t, c.ChangeTransport(courier.Bicycle))
+	assert.Equal(t, 2, c.Speed())
+	assert.Error(t, c.ChangeTransport(courier.Transport{}))
+	assert.Equal(t, courier.Bicycle, c.Transport())
+}
+
+func Test_ChangeTransport_StoragePlacesMustFit(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.AddStoragePlace("Trunk", 50)
+
+	assert.ErrorIs(t, c.ChangeTransport(courier.Bicycle), courier.ErrTransportCapacityExceeded)
+	assert.Equal(t, courier.Car, c.Transport())
+}
+
+func Test_AddStoragePlace_TransportCapacity(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Pedestrian, validLocation())
+
+	assert.NoError(t, c.AddStoragePlace("Bag", 6))
+	assert.ErrorIs(t, c.AddStoragePlace("Backpack", 5), courier.ErrTransportCapacityExceeded)
+	assert.NoError(t, c.AddStoragePlace("Pocket", 4))
+}
+
+func Test_AddDefaultBag_FitsTransport(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Pedestrian, validLocation())
+
+	assert.NoError(t, c.AddDefaultBag(fixedRandomSource(courier.MaxDefaultBagVolume)))
+	assert.Equal(t, courier.Pedestrian.Capacity(), c.StoragePlaces()[0].TotalVolume())
+}
+
+func Test_CanTakeOrder_RestrictedByTransport(t *testing.T) {
+	bigOrder, _ := order.NewOrder(uuid.New(), targetLocation(), 25)
+	for _, transport := range []courier.Transport{courier.Pedestrian, courier.Bicycle, courier.Car} {
+		c, _ := courier.NewCourier("CourierName", transport, validLocation())
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Storage", transport.Capacity())
+
+		canTake, err := c.CanTakeOrder(bigOrder)
+		assert.NoError(t, err)
+		assert.Equal(t, transport.Equals(courier.Car), canTake, transport.Name())
+	}
+}
+
+func Test_RemoveStoragePlace(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Bag", 10)
+	_ = c.AddStoragePlace("Trunk", 90)
+	trunk := c.StoragePlaces()[1]
+
+	assert.ErrorIs(t, c.RemoveStoragePlace(uuid.New()), courier.ErrStoragePlaceNotFound)
+	assert.NoError(t, c.RemoveStoragePlace(trunk.ID()))
+	assert.Len(t, c.StoragePlaces(), 1)
+	assert.Equal(t, "Bag", c.StoragePlaces()[0].Name())
+}
+
+func Test_RemoveStoragePlace_WithOrder(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Trunk", 100)
+
+	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
+	_ = c.TakeOrder(o)
+
+	err := c.RemoveStoragePlace(c.StoragePlaces()[0].ID())
+	assert.ErrorIs(t, err, courier.ErrStoragePlaceNotEmpty)
+	assert.Len(t, c.StoragePlaces(), 1)
+}
+
+func Test_Deactivate(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Car, validLocation())
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Storage", 10)
+
+	o, _ := order.NewOrder(uuid.New(), targetLocation(), 5)
+	_ = c.TakeOrder(o)
+	assert.ErrorIs(t, c.Deactivate(), courier.ErrCourierHasOrders)
+
+	_ = c.CompleteOrder(o)
+	assert.NoError(t, c.Deactivate())
+	assert.Equal(t, courier.StatusDeactivated, c.Status())
+
+	assert.ErrorIs(t, c.StartShift(), courier.ErrCourierDeactivated)
+	assert.ErrorIs(t, c.Rename("NewName"), courier.ErrCourierDeactivated)
+	assert.ErrorIs(t, c.AddStoragePlace("Trunk", 100), courier.ErrCourierDeactivated)
+	assert.ErrorIs(t, c.Deactivate(), courier.ErrCourierDeactivated)
+}
+
+func Test_StepTowardsCoordinate(t *testing.T) {
+	c, _ := courier.NewCourier("CourierName", courier.Bicycle, validLocation())
+	start, _ := kernel.NewGeoCoordinate(55.7558, 37.6173)
+	target, _ := kernel.NewGeoCoordinate(55.7658, 37.6173)
+
+	// Без координат курьер по карте не ездит
+	assert.ErrorIs(t, c.StepTowardsCoordinate(target), courier.ErrNoCoordinate)
+
+	// 15 км/ч - около 41.7 м за десятисекундный шаг, до цели около 1112 м
+	assert.NoError(t, c.SetCoordinate(start))
+	steps, err := c.GeoStepsTo(target)
+	assert.NoError(t, err)
+	assert.InDelta(t, 26.7, steps, 0.1)
+
+	assert.NoError(t, c.StepTowardsCoordinate(target))
+	distance, _ := c.Coordinate().DistanceTo(target)
+	assert.InDelta(t, 1070, distance, 1)
+
+	// Последний шаг короче скорости - курьер останавливается ровно в точке
+	for i := 1; i < 27; i++ {
+		assert.False(t, c.Coordinate().Equals(target))
+		assert.NoError(t, c.StepTowardsCoordinate(target))
+	}
+	assert.True(t, c.Coordinate().Equals(target))
+}
+
+func Test_StepTowards_AroundBlockedCells(t *testing.T) {
+	grid, _ := kernel.NewGrid("test", 1, 1, 3, 3)
+	grid, _ = grid.WithBlockedCells(kernel.Cell{X: 2, Y: 1}, kernel.Cell{X: 2, Y: 2})
+	start, _ := grid.NewLocation(1, 1)
+	target, _ := grid.NewLocation(3, 1)
+	c, _ := courier.NewCourier("CourierName", courier.Pedestrian, start)
+
+	steps, err := c.StepsTo(target)
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, steps)
+
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, c.StepTowards(target))
+		assert.False(t, grid.IsBlocked(c.Location().X(), c.Location().Y()))
+	}
+	assert.True(t, c.Location().Equals(target))
+}
+
+func Test_StepTowards_MovesSpeedCells(t *testing.T) {
+	start, _ := kernel.NewLocation(1, 1)
+	target, _ := kernel.NewLocation(5, 5)
+	c, _ := courier.NewCourier("Car", courier.Car, start)
+
+	assert.NoError(t, c.StepTowards(target))
+	distance, _ := c.Location().DistanceTo(target)
+	assert.Equal(t, 5, distance)
+
+	assert.NoError(t, c.StepTowards(target))
+	distance, _ = c.Location().DistanceTo(target)
+	assert.Equal(t, 2, distance)
+
+	// До цели ближе скорости - курьер приезжает посреди шага и дальше не едет
+	assert.NoError(t, c.StepTowards(target))
+	assert.True(t, c.Location().Equals(target))
+	assert.NoError(t, c.StepTowards(target))
+	assert.True(t, c.Location().Equals(target))
+}
+
+func Test_ArrivalTime_MatchesSimulatedMovement(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	grid, _ := kernel.NewGrid("test", 1, 1, 10, 10)
+	blockedGrid, _ := grid.WithBlockedCells(kernel.Cell{X: 5, Y: 1}, kernel.Cell{X: 5, Y: 2}, kernel.Cell{X: 5, Y: 3})
+
+	for _, g := range []kernel.Grid{grid, blockedGrid} {
+		for speed := 1; speed <= 4; speed++ {
+			start, _ := g.NewLocation(1, 1)
+			target, _ := g.NewLocation(9, 2)
+			transport, _ := courier.NewTransport("Test", speed, 15, 20)
+			c, _ := courier.NewCourier("Courier", transport, start)
+
+			eta, err := c.ArrivalTime(target, now)
+			assert.NoError(t, err)
+
+			ticks := 0
+			for !c.Location().Equals(target) {
+				assert.NoError(t, c.StepTowards(target))
+				ticks++
+			}
+			assert.Equal(t, eta, now.Add(time.Duration(ticks)*courier.StepDuration), "speed %d", speed)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package courier
+
+const (
+	StatusEmpty   Status = ""
+	StatusOffDuty Status = "OffDuty"
+	StatusOnShift Status = "OnShift"
+	StatusOnBreak Status = "OnBreak"
+
+	// StatusDeactivated - курьер больше не работает. Запись о нем остается ради истории заказов
+	StatusDeactivated Status = "Deactivated"
+)
+
+type Status string
+
+func (s Status) Equal(other Status) bool {
+	return s == other
+}
+
+func (s Status) IsEmpty() bool {
+	return s == StatusEmpty
+}
+
+func (s Status) String() string {
+	return string(s)
+}
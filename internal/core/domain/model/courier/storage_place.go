@@ -7,14 +7,21 @@ import (
 )
 
 type StoragePlace struct {
-	id          uuid.UUID
-	name        string
-	totalVolume int
-	orderId     *uuid.UUID
+	id           uuid.UUID
+	name         string
+	totalVolume  int
+	storedOrders []StoredOrder
+}
+
+// StoredOrder - заказ, лежащий в месте хранения, и занимаемый им объем
+type StoredOrder struct {
+	orderID uuid.UUID
+	volume  int
 }
 
 var (
-	ErrCannotStoreOrder = errors.New("cannot store order: place is not empty or volume too large")
+	ErrCannotStoreOrder   = errors.New("cannot store order: not enough free volume")
+	ErrOrderAlreadyStored = errors.New("order is already stored")
 )
 
 func NewStoragePlace(name string, totalVolume int) (*StoragePlace, error) {
@@ -25,10 +32,10 @@ func NewStoragePlace(name string, totalVolume int) (*StoragePlace, error) {
 		return nil, errs.NewValueIsRequiredError("totalVolume")
 	}
 	return &StoragePlace{
-		id:          uuid.New(),
-		name:        name,
-		totalVolume: totalVolume,
-		orderId:     nil,
+		id:           uuid.New(),
+		name:         name,
+		totalVolume:  totalVolume,
+		storedOrders: make([]StoredOrder, 0),
 	}, nil
 }
 
@@ -44,19 +51,50 @@ func (s *StoragePlace) TotalVolume() int {
 	return s.totalVolume
 }
 
-func (s *StoragePlace) OrderID() *uuid.UUID {
-	return s.orderId
+func (s *StoragePlace) UsedVolume() int {
+	usedVolume := 0
+	for _, storedOrder := range s.storedOrders {
+		usedVolume += storedOrder.volume
+	}
+	return usedVolume
+}
+
+func (s *StoragePlace) FreeVolume() int {
+	return s.totalVolume - s.UsedVolume()
+}
+
+func (s *StoragePlace) StoredOrders() []StoredOrder {
+	res := make([]StoredOrder, len(s.storedOrders))
+	copy(res, s.storedOrders)
+	return res
+}
+
+func (s *StoragePlace) OrderIDs() []uuid.UUID {
+	orderIDs := make([]uuid.UUID, len(s.storedOrders))
+	for i, storedOrder := range s.storedOrders {
+		orderIDs[i] = storedOrder.orderID
+	}
+	return orderIDs
+}
+
+func (s *StoragePlace) Contains(orderId uuid.UUID) bool {
+	for _, storedOrder := range s.storedOrders {
+		if storedOrder.orderID == orderId {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *StoragePlace) IsEmpty() bool {
-	return s.orderId == nil
+	return len(s.storedOrders) == 0
 }
 
 func (s *StoragePlace) CanStore(orderVolume int) bool {
 	if orderVolume <= 0 {
 		return false
 	}
-	return s.IsEmpty() && orderVolume <= s.totalVolume
+	return orderVolume <= s.FreeVolume()
 }
 
 func (s *StoragePlace) StoreOrder(orderId uuid.UUID, orderVolume int) error {
@@ -66,15 +104,27 @@ func (s *StoragePlace) StoreOrder(orderId uuid.UUID, orderVolume int) error {
 	if orderVolume <= 0 {
 		return errs.NewValueIsRequiredError("volume")
 	}
+	if s.Contains(orderId) {
+		return ErrOrderAlreadyStored
+	}
 	if !s.CanStore(orderVolume) {
 		return ErrCannotStoreOrder
 	}
-	s.orderId = &orderId
+	s.storedOrders = append(s.storedOrders, StoredOrder{
+		orderID: orderId,
+		volume:  orderVolume,
+	})
 	return nil
 }
 
-func (s *StoragePlace) RemoveOrder() {
-	s.orderId = nil
+func (s *StoragePlace) RemoveOrder(orderId uuid.UUID) error {
+	for i, storedOrder := range s.storedOrders {
+		if storedOrder.orderID == orderId {
+			s.storedOrders = append(s.storedOrders[:i:i], s.storedOrders[i+1:]...)
+			return nil
+		}
+	}
+	return errs.NewObjectNotFoundError("orderId", orderId)
 }
 
 func (s *StoragePlace) Equals(other *StoragePlace) bool {
@@ -84,11 +134,29 @@ func (s *StoragePlace) Equals(other *StoragePlace) bool {
 	return s.id == other.id
 }
 
-func RestoreStoragePlace(id uuid.UUID, name string, totalVolume int, orderID *uuid.UUID) *StoragePlace {
+func RestoreStoragePlace(id uuid.UUID, name string, totalVolume int, storedOrders []StoredOrder) *StoragePlace {
+	if storedOrders == nil {
+		storedOrders = make([]StoredOrder, 0)
+	}
 	return &StoragePlace{
-		id:          id,
-		name:        name,
-		totalVolume: totalVolume,
-		orderId:     orderID,
+		id:           id,
+		name:         name,
+		totalVolume:  totalVolume,
+		storedOrders: storedOrders,
+	}
+}
+
+func (s StoredOrder) OrderID() uuid.UUID {
+	return s.orderID
+}
+
+func (s StoredOrder) Volume() int {
+	return s.volume
+}
+
+func RestoreStoredOrder(orderID uuid.UUID, volume int) StoredOrder {
+	return StoredOrder{
+		orderID: orderID,
+		volume:  volume,
 	}
 }
@@ -2,6 +2,7 @@ package courier_test
 
 import (
 	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/pkg/errs"
 	"errors"
 	"github.com/google/uuid"
 	"testing"
@@ -20,7 +21,9 @@ func Test_NewStoragePlace_Success(t *testing.T) {
 	assert.Equal(t, name, sp.Name())
 	assert.Equal(t, totalVolume, sp.TotalVolume())
 	assert.True(t, sp.IsEmpty())
-	assert.Nil(t, sp.OrderID())
+	assert.Empty(t, sp.OrderIDs())
+	assert.Equal(t, 0, sp.UsedVolume())
+	assert.Equal(t, totalVolume, sp.FreeVolume())
 }
 
 func Test_StoragePlace_NewStoragePlace_Empty_Name(t *testing.T) {
@@ -50,11 +53,13 @@ func Test_StoragePlace_CanStore(t *testing.T) {
 
 	assert.True(t, sp.CanStore(5))
 	assert.False(t, sp.CanStore(15))
+	assert.False(t, sp.CanStore(0))
 
 	orderID := uuid.New()
 	_ = sp.StoreOrder(orderID, 5)
 
-	assert.False(t, sp.CanStore(5))
+	assert.True(t, sp.CanStore(5))
+	assert.False(t, sp.CanStore(6))
 }
 
 func Test_StoragePlace_StoreOrder_Success(t *testing.T) {
@@ -64,7 +69,8 @@ func Test_StoragePlace_StoreOrder_Success(t *testing.T) {
 	err := sp.StoreOrder(orderID, 5)
 
 	assert.NoError(t, err)
-	assert.Equal(t, orderID, *sp.OrderID())
+	assert.Equal(t, []uuid.UUID{orderID}, sp.OrderIDs())
+	assert.True(t, sp.Contains(orderID))
 	assert.False(t, sp.IsEmpty())
 }
 
@@ -110,9 +116,74 @@ func Test_StoragePlace_RemoveOrder(t *testing.T) {
 
 	assert.False(t, sp.IsEmpty())
 
-	sp.RemoveOrder()
+	err := sp.RemoveOrder(orderID)
+	assert.NoError(t, err)
+	assert.True(t, sp.IsEmpty())
+	assert.Empty(t, sp.OrderIDs())
+}
+
+func Test_StoragePlace_RemoveOrder_NotFound(t *testing.T) {
+	sp, _ := courier.NewStoragePlace("bag", 10)
+	_ = sp.StoreOrder(uuid.New(), 5)
+
+	err := sp.RemoveOrder(uuid.New())
+
+	assert.ErrorIs(t, err, errs.ErrObjectNotFound)
+	assert.Equal(t, 5, sp.UsedVolume())
+}
+
+func Test_StoragePlace_StoreOrder_Fail_AlreadyStored(t *testing.T) {
+	sp, _ := courier.NewStoragePlace("bag", 10)
+	orderID := uuid.New()
+	_ = sp.StoreOrder(orderID, 2)
+
+	err := sp.StoreOrder(orderID, 2)
+
+	assert.ErrorIs(t, err, courier.ErrOrderAlreadyStored)
+	assert.Equal(t, 2, sp.UsedVolume())
+}
+
+func Test_StoragePlace_FillAndEmpty(t *testing.T) {
+	sp, _ := courier.NewStoragePlace("bag", 30)
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	// Заполняем до конца
+	assert.NoError(t, sp.StoreOrder(first, 2))
+	assert.NoError(t, sp.StoreOrder(second, 18))
+	assert.NoError(t, sp.StoreOrder(third, 10))
+	assert.Equal(t, 30, sp.UsedVolume())
+	assert.Equal(t, 0, sp.FreeVolume())
+	assert.False(t, sp.CanStore(1))
+	assert.ErrorIs(t, sp.StoreOrder(uuid.New(), 1), courier.ErrCannotStoreOrder)
+	assert.Equal(t, []uuid.UUID{first, second, third}, sp.OrderIDs())
+
+	// Освобождаем место из середины и снова заполняем
+	assert.NoError(t, sp.RemoveOrder(second))
+	assert.Equal(t, 12, sp.UsedVolume())
+	assert.Equal(t, 18, sp.FreeVolume())
+	assert.False(t, sp.Contains(second))
+	assert.False(t, sp.CanStore(19))
+	assert.NoError(t, sp.StoreOrder(second, 18))
+	assert.Equal(t, 0, sp.FreeVolume())
+
+	// Опустошаем
+	assert.NoError(t, sp.RemoveOrder(first))
+	assert.NoError(t, sp.RemoveOrder(third))
+	assert.NoError(t, sp.RemoveOrder(second))
 	assert.True(t, sp.IsEmpty())
-	assert.Nil(t, sp.OrderID())
+	assert.Equal(t, 30, sp.FreeVolume())
+}
+
+func Test_StoragePlace_StoredOrders(t *testing.T) {
+	sp, _ := courier.NewStoragePlace("bag", 10)
+	orderID := uuid.New()
+	_ = sp.StoreOrder(orderID, 4)
+
+	storedOrders := sp.StoredOrders()
+
+	assert.Len(t, storedOrders, 1)
+	assert.Equal(t, orderID, storedOrders[0].OrderID())
+	assert.Equal(t, 4, storedOrders[0].Volume())
 }
 
 func Test_StoragePlace_Equals(t *testing.T) {
@@ -0,0 +1,109 @@
+package courier
+
+import (
+	"delivery/internal/pkg/errs"
+	"fmt"
+	"time"
+)
+
+// Transport - на чем ездит курьер: от транспорта зависят скорость и сколько курьер может везти.
+// Скоростей две: speed - клетки сетки за шаг, geoSpeed - км/ч, с ней курьер едет по координатам
+type Transport struct {
+	name     string
+	speed    int
+	geoSpeed int
+	capacity int
+}
+
+const (
+	TransportPedestrian = "pedestrian"
+	TransportBicycle    = "bicycle"
+	TransportCar        = "car"
+)
+
+// Стандартные профили транспорта. Заказ объемом больше 20 может взять только курьер на машине
+var (
+	Pedestrian = Transport{name: TransportPedestrian, speed: 1, geoSpeed: 5, capacity: 10}
+	Bicycle    = Transport{name: TransportBicycle, speed: 2, geoSpeed: 15, capacity: 20}
+	Car        = Transport{name: TransportCar, speed: 3, geoSpeed: 30, capacity: 100}
+)
+
+// NewTransport создает нестандартный профиль транспорта: speed - клетки за шаг, geoSpeed - км/ч
+func NewTransport(name string, speed int, geoSpeed int, capacity int) (Transport, error) {
+	if name == "" {
+		return Transport{}, errs.NewValueIsRequiredError("name")
+	}
+	if speed <= 0 {
+		return Transport{}, errs.NewValueIsRequiredError("speed")
+	}
+	if geoSpeed <= 0 {
+		return Transport{}, errs.NewValueIsRequiredError("geoSpeed")
+	}
+	if capacity <= 0 {
+		return Transport{}, errs.NewValueIsRequiredError("capacity")
+	}
+	return Transport{name: name, speed: speed, geoSpeed: geoSpeed, capacity: capacity}, nil
+}
+
+// TransportByName возвращает стандартный профиль по имени
+func TransportByName(name string) (Transport, error) {
+	switch name {
+	case TransportPedestrian:
+		return Pedestrian, nil
+	case TransportBicycle:
+		return Bicycle, nil
+	case TransportCar:
+		return Car, nil
+	default:
+		return Transport{}, errs.NewValueIsInvalidErrorWithCause("transport",
+			fmt.Errorf("unknown transport %q", name))
+	}
+}
+
+// RestoreTransport восстанавливает профиль из хранилища без проверок. Профили, сохраненные
+// до появления скорости по координатам, получают скорость стандартного профиля с тем же именем или велосипеда
+func RestoreTransport(name string, speed int, geoSpeed int, capacity int) Transport {
+	if geoSpeed <= 0 {
+		geoSpeed = Bicycle.geoSpeed
+		if standard, err := TransportByName(name); err == nil {
+			geoSpeed = standard.geoSpeed
+		}
+	}
+	return Transport{name: name, speed: speed, geoSpeed: geoSpeed, capacity: capacity}
+}
+
+func (t Transport) Name() string {
+	return t.name
+}
+
+func (t Transport) Speed() int {
+	return t.speed
+}
+
+// GeoSpeed - скорость по координатам, км/ч
+func (t Transport) GeoSpeed() int {
+	return t.geoSpeed
+}
+
+// MetresPerStep - сколько метров курьер проезжает по координатам за один шаг
+func (t Transport) MetresPerStep() float64 {
+	return float64(t.geoSpeed) * 1000 / time.Hour.Seconds() * StepDuration.Seconds()
+}
+
+// Capacity - суммарный объем мест хранения, который помещается на этот транспорт
+func (t Transport) Capacity() int {
+	return t.capacity
+}
+
+// CanCarry - помещается ли на этот транспорт заказ такого объема
+func (t Transport) CanCarry(volume int) bool {
+	return volume <= t.capacity
+}
+
+func (t Transport) IsEmpty() bool {
+	return t == Transport{}
+}
+
+func (t Transport) Equals(other Transport) bool {
+	return t == other
+}
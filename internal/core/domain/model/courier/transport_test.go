@@ -0,0 +1,52 @@
+package courier_test
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_TransportByName(t *testing.T) {
+	for _, transport := range []courier.Transport{courier.Pedestrian, courier.Bicycle, courier.Car} {
+		found, err := courier.TransportByName(transport.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, transport, found)
+	}
+
+	_, err := courier.TransportByName("helicopter")
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func Test_NewTransport(t *testing.T) {
+	transport, err := courier.NewTransport("Scooter", 2, 20, 15)
+	assert.NoError(t, err)
+	assert.Equal(t, "Scooter", transport.Name())
+	assert.Equal(t, 2, transport.Speed())
+	assert.Equal(t, 20, transport.GeoSpeed())
+	assert.Equal(t, 15, transport.Capacity())
+	assert.True(t, transport.CanCarry(15))
+	assert.False(t, transport.CanCarry(16))
+
+	_, err = courier.NewTransport("", 2, 20, 15)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	_, err = courier.NewTransport("Scooter", 0, 20, 15)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	_, err = courier.NewTransport("Scooter", 2, 0, 15)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	_, err = courier.NewTransport("Scooter", 2, 20, 0)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func Test_Transport_MetresPerStep(t *testing.T) {
+	// 36 км/ч - 10 м/с, шаг длится 10 секунд
+	transport, _ := courier.NewTransport("Scooter", 2, 36, 15)
+	assert.InDelta(t, 100, transport.MetresPerStep(), 1e-9)
+	assert.InDelta(t, 13.9, courier.Pedestrian.MetresPerStep(), 0.1)
+}
+
+func Test_RestoreTransport_FillsMissingGeoSpeed(t *testing.T) {
+	assert.Equal(t, courier.Car, courier.RestoreTransport(courier.TransportCar, 3, 0, 100))
+	assert.Equal(t, courier.Bicycle.GeoSpeed(), courier.RestoreTransport("custom", 2, 0, 1000).GeoSpeed())
+	assert.Equal(t, 20, courier.RestoreTransport("custom", 2, 20, 1000).GeoSpeed())
+}
@@ -0,0 +1,66 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"strings"
+)
+
+// Address - адрес доставки. Обязательна только улица: по ней геосервис определяет координаты
+type Address struct {
+	country   string
+	city      string
+	street    string
+	house     string
+	apartment string
+}
+
+func NewAddress(country string, city string, street string, house string, apartment string) (Address, error) {
+	if street == "" {
+		return Address{}, errs.NewValueIsRequiredError("street")
+	}
+	return Address{
+		country:   country,
+		city:      city,
+		street:    street,
+		house:     house,
+		apartment: apartment,
+	}, nil
+}
+
+func (a Address) Country() string {
+	return a.country
+}
+
+func (a Address) City() string {
+	return a.city
+}
+
+func (a Address) Street() string {
+	return a.street
+}
+
+func (a Address) House() string {
+	return a.house
+}
+
+func (a Address) Apartment() string {
+	return a.apartment
+}
+
+func (a Address) IsEmpty() bool {
+	return a == Address{}
+}
+
+func (a Address) Equals(other Address) bool {
+	return a == other
+}
+
+func (a Address) String() string {
+	parts := make([]string, 0, 5)
+	for _, part := range []string{a.country, a.city, a.street, a.house, a.apartment} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
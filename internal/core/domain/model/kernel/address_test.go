@@ -0,0 +1,39 @@
+package kernel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Address_Valid(t *testing.T) {
+	// Steps
+	address, err := NewAddress("Россия", "Москва", "Тверская", "1", "10")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Россия", address.Country())
+	assert.Equal(t, "Москва", address.City())
+	assert.Equal(t, "Тверская", address.Street())
+	assert.Equal(t, "1", address.House())
+	assert.Equal(t, "10", address.Apartment())
+	assert.False(t, address.IsEmpty())
+	assert.Equal(t, "Россия, Москва, Тверская, 1, 10", address.String())
+}
+
+func Test_Address_OnlyStreet(t *testing.T) {
+	// Steps
+	address, err := NewAddress("", "", "Тверская", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Тверская", address.String())
+}
+
+func Test_Address_Error_EmptyStreet(t *testing.T) {
+	// Steps
+	address, err := NewAddress("Россия", "Москва", "", "1", "10")
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, address.IsEmpty())
+}
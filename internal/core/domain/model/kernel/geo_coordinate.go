@@ -0,0 +1,105 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"math"
+)
+
+// EarthRadius - средний радиус Земли в метрах
+const EarthRadius = 6371000.0
+
+// GeoCoordinate - точка на поверхности Земли: широта и долгота в градусах.
+// В отличие от Location не привязана к сетке и нужна для реальных адресов из геокодера
+type GeoCoordinate struct {
+	latitude  float64
+	longitude float64
+
+	isSet bool
+}
+
+func NewGeoCoordinate(latitude, longitude float64) (GeoCoordinate, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return GeoCoordinate{}, errs.NewValueIsOutOfRangeError("latitude", latitude, -90, 90)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return GeoCoordinate{}, errs.NewValueIsOutOfRangeError("longitude", longitude, -180, 180)
+	}
+	return GeoCoordinate{
+		latitude:  latitude,
+		longitude: longitude,
+		isSet:     true,
+	}, nil
+}
+
+func (c GeoCoordinate) Latitude() float64 {
+	return c.latitude
+}
+
+func (c GeoCoordinate) Longitude() float64 {
+	return c.longitude
+}
+
+func (c GeoCoordinate) IsEmpty() bool {
+	return !c.isSet
+}
+
+func (c GeoCoordinate) Equals(other GeoCoordinate) bool {
+	return c == other
+}
+
+// DistanceTo - расстояние по поверхности Земли в метрах (формула гаверсинусов)
+func (c GeoCoordinate) DistanceTo(other GeoCoordinate) (float64, error) {
+	if c.IsEmpty() {
+		return 0, errs.NewValueIsRequiredError("origin coordinate")
+	}
+	if other.IsEmpty() {
+		return 0, errs.NewValueIsRequiredError("target coordinate")
+	}
+	return EarthRadius * c.angleTo(other), nil
+}
+
+// MoveTowards сдвигает точку к target по прямой (дуге большого круга) на metres метров.
+// Если до target ближе, возвращает сам target
+func (c GeoCoordinate) MoveTowards(target GeoCoordinate, metres float64) (GeoCoordinate, error) {
+	distance, err := c.DistanceTo(target)
+	if err != nil {
+		return GeoCoordinate{}, err
+	}
+	if metres < 0 {
+		return GeoCoordinate{}, errs.NewValueIsOutOfRangeError("metres", metres, 0, math.Inf(1))
+	}
+	if distance <= metres {
+		return target, nil
+	}
+
+	// Промежуточная точка на дуге большого круга, fraction - пройденная доля пути
+	fraction := metres / distance
+	angle := c.angleTo(target)
+	lat1, lon1 := toRadians(c.latitude), toRadians(c.longitude)
+	lat2, lon2 := toRadians(target.latitude), toRadians(target.longitude)
+	a := math.Sin((1-fraction)*angle) / math.Sin(angle)
+	b := math.Sin(fraction*angle) / math.Sin(angle)
+	x := a*math.Cos(lat1)*math.Cos(lon1) + b*math.Cos(lat2)*math.Cos(lon2)
+	y := a*math.Cos(lat1)*math.Sin(lon1) + b*math.Cos(lat2)*math.Sin(lon2)
+	z := a*math.Sin(lat1) + b*math.Sin(lat2)
+
+	return NewGeoCoordinate(toDegrees(math.Atan2(z, math.Sqrt(x*x+y*y))), toDegrees(math.Atan2(y, x)))
+}
+
+// angleTo - центральный угол между точками в радианах
+func (c GeoCoordinate) angleTo(other GeoCoordinate) float64 {
+	lat1, lat2 := toRadians(c.latitude), toRadians(other.latitude)
+	dLat := lat2 - lat1
+	dLon := toRadians(other.longitude - c.longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,47 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_NewGeoCoordinate_OutOfRange(t *testing.T) {
+	_, err := NewGeoCoordinate(91, 0)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	_, err = NewGeoCoordinate(0, -181)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func Test_GeoCoordinate_DistanceTo(t *testing.T) {
+	// Красная площадь - Дворцовая площадь, около 634 км
+	moscow, _ := NewGeoCoordinate(55.7539, 37.6208)
+	petersburg, _ := NewGeoCoordinate(59.9390, 30.3158)
+
+	distance, err := moscow.DistanceTo(petersburg)
+	assert.NoError(t, err)
+	assert.InDelta(t, 634000, distance, 2000)
+
+	_, err = moscow.DistanceTo(GeoCoordinate{})
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func Test_GeoCoordinate_MoveTowards(t *testing.T) {
+	origin, _ := NewGeoCoordinate(55.7539, 37.6208)
+	target, _ := NewGeoCoordinate(55.7600, 37.6300)
+	total, _ := origin.DistanceTo(target)
+
+	// Шаг меньше расстояния - точка на прямой между origin и target
+	moved, err := origin.MoveTowards(target, 100)
+	assert.NoError(t, err)
+	passed, _ := origin.DistanceTo(moved)
+	left, _ := moved.DistanceTo(target)
+	assert.InDelta(t, 100, passed, 0.01)
+	assert.InDelta(t, total-100, left, 0.01)
+
+	// Шаг больше расстояния - ровно в target
+	moved, err = origin.MoveTowards(target, total+1)
+	assert.NoError(t, err)
+	assert.True(t, moved.Equals(target))
+}
@@ -0,0 +1,195 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"errors"
+)
+
+var (
+	ErrDifferentGrids = errors.New("locations belong to different grids")
+	ErrNoFreeCells    = errors.New("all cells of the grid are blocked")
+)
+
+// DefaultZone - зона исторической карты 10x10
+const DefaultZone = "default"
+
+// Grid - карта города или зоны доставки: прямоугольник клеток, границы включаются.
+// Часть клеток может быть перекрыта (река, закрытая дорога, пешеходная зона) - через них курьеры не ездят.
+// Зона - имя карты, по нему точка восстанавливается на своей карте после чтения из базы
+type Grid struct {
+	zone string
+	minX int
+	minY int
+	maxX int
+	maxY int
+
+	// blocked не меняется после создания карты, поэтому копии Grid могут делить его между собой
+	blocked map[Cell]struct{}
+}
+
+// Cell - координаты клетки без привязки к карте, нужны для описания перекрытых клеток
+type Cell struct {
+	X int
+	Y int
+}
+
+func NewGrid(zone string, minX, minY, maxX, maxY int) (Grid, error) {
+	if zone == "" {
+		return Grid{}, errs.NewValueIsRequiredError("zone")
+	}
+	if minX < 0 {
+		return Grid{}, errs.NewValueIsOutOfRangeError("minX", minX, 0, maxX)
+	}
+	if minY < 0 {
+		return Grid{}, errs.NewValueIsOutOfRangeError("minY", minY, 0, maxY)
+	}
+	if maxX < minX {
+		return Grid{}, errs.NewValueIsInvalidError("maxX")
+	}
+	if maxY < minY {
+		return Grid{}, errs.NewValueIsInvalidError("maxY")
+	}
+	return Grid{zone: zone, minX: minX, minY: minY, maxX: maxX, maxY: maxY}, nil
+}
+
+// WithBlockedCells возвращает копию карты, на которой перекрыты переданные клетки
+func (g Grid) WithBlockedCells(cells ...Cell) (Grid, error) {
+	if g.IsEmpty() {
+		return Grid{}, errs.NewValueIsRequiredError("grid")
+	}
+	blocked := make(map[Cell]struct{}, len(g.blocked)+len(cells))
+	for cell := range g.blocked {
+		blocked[cell] = struct{}{}
+	}
+	for _, cell := range cells {
+		if err := g.validate(cell.X, cell.Y); err != nil {
+			return Grid{}, err
+		}
+		blocked[cell] = struct{}{}
+	}
+
+	result := g
+	result.blocked = blocked
+	if len(blocked) == 0 {
+		result.blocked = nil
+	}
+	return result, nil
+}
+
+// DefaultGrid - историческая карта 10x10 без перекрытий, с ней работают NewLocation, MinLocation,
+// MaxLocation и CreateRandom. Карта города сервиса задается конфигурацией и передается явно
+func DefaultGrid() Grid {
+	return Grid{zone: DefaultZone, minX: 1, minY: 1, maxX: 10, maxY: 10}
+}
+
+func (g Grid) Zone() string {
+	return g.zone
+}
+
+func (g Grid) MinX() int {
+	return g.minX
+}
+
+func (g Grid) MinY() int {
+	return g.minY
+}
+
+func (g Grid) MaxX() int {
+	return g.maxX
+}
+
+func (g Grid) MaxY() int {
+	return g.maxY
+}
+
+// BlockedCells возвращает перекрытые клетки, порядок не гарантируется
+func (g Grid) BlockedCells() []Cell {
+	cells := make([]Cell, 0, len(g.blocked))
+	for cell := range g.blocked {
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+func (g Grid) IsBlocked(x, y int) bool {
+	_, ok := g.blocked[Cell{X: x, Y: y}]
+	return ok
+}
+
+func (g Grid) IsEmpty() bool {
+	return g.zone == "" && g.minX == 0 && g.minY == 0 && g.maxX == 0 && g.maxY == 0 && len(g.blocked) == 0
+}
+
+func (g Grid) Equals(other Grid) bool {
+	if g.zone != other.zone || g.minX != other.minX || g.minY != other.minY || g.maxX != other.maxX || g.maxY != other.maxY {
+		return false
+	}
+	if len(g.blocked) != len(other.blocked) {
+		return false
+	}
+	for cell := range g.blocked {
+		if _, ok := other.blocked[cell]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// NewLocation создает точку на этой карте
+func (g Grid) NewLocation(x, y int) (Location, error) {
+	if err := g.validate(x, y); err != nil {
+		return Location{}, err
+	}
+	return Location{
+		x:    x,
+		y:    y,
+		grid: g,
+	}, nil
+}
+
+func (g Grid) MinLocation() Location {
+	location, err := g.NewLocation(g.minX, g.minY)
+	if err != nil {
+		panic("invalid min location configuration")
+	}
+	return location
+}
+
+func (g Grid) MaxLocation() Location {
+	location, err := g.NewLocation(g.maxX, g.maxY)
+	if err != nil {
+		panic("invalid max location configuration")
+	}
+	return location
+}
+
+// CreateRandom выбирает случайную свободную точку на этой карте, используя переданный источник случайных чисел
+func (g Grid) CreateRandom(source RandomSource) (Location, error) {
+	if source == nil {
+		return Location{}, errs.NewValueIsRequiredError("source")
+	}
+	width, height := g.maxX-g.minX+1, g.maxY-g.minY+1
+	if len(g.blocked) >= width*height {
+		return Location{}, ErrNoFreeCells
+	}
+	for {
+		x := source.Intn(width) + g.minX
+		y := source.Intn(height) + g.minY
+		if !g.IsBlocked(x, y) {
+			return g.NewLocation(x, y)
+		}
+	}
+}
+
+func (g Grid) validate(x, y int) error {
+	if g.IsEmpty() {
+		return errs.NewValueIsRequiredError("grid")
+	}
+	if x < g.minX || x > g.maxX {
+		return errs.NewValueIsOutOfRangeError("x", x, g.minX, g.maxX)
+	}
+	if y < g.minY || y > g.maxY {
+		return errs.NewValueIsOutOfRangeError("y", y, g.minY, g.maxY)
+	}
+	return nil
+}
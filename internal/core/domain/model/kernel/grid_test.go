@@ -0,0 +1,110 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_DefaultGrid_KeepsHistoricalBounds(t *testing.T) {
+	grid := DefaultGrid()
+
+	assert.Equal(t, 1, grid.MinX())
+	assert.Equal(t, 1, grid.MinY())
+	assert.Equal(t, 10, grid.MaxX())
+	assert.Equal(t, 10, grid.MaxY())
+	assert.True(t, MinLocation().Grid().Equals(grid))
+}
+
+func Test_NewGrid_Invalid(t *testing.T) {
+	_, err := NewGrid("test", 5, 1, 4, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+
+	_, err = NewGrid("test", -1, 1, 4, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func Test_Grid_NewLocation_ValidatesBounds(t *testing.T) {
+	grid, err := NewGrid("test", 1, 1, 50, 30)
+	assert.NoError(t, err)
+
+	location, err := grid.NewLocation(40, 25)
+	assert.NoError(t, err)
+	assert.NoError(t, location.IsValid())
+
+	_, err = grid.NewLocation(40, 31)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	// На карте по умолчанию такой точки нет
+	_, err = NewLocation(40, 25)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+}
+
+func Test_Grid_MinMaxAndRandomLocation(t *testing.T) {
+	grid, _ := NewGrid("test", 5, 5, 20, 8)
+
+	assert.Equal(t, 5, grid.MinLocation().X())
+	assert.Equal(t, 5, grid.MinLocation().Y())
+	assert.Equal(t, 20, grid.MaxLocation().X())
+	assert.Equal(t, 8, grid.MaxLocation().Y())
+
+	source := NewRandomSource(7)
+	for i := 0; i < 100; i++ {
+		location, err := grid.CreateRandom(source)
+		assert.NoError(t, err)
+		assert.NoError(t, location.IsValid())
+	}
+}
+
+func Test_Location_DistanceTo_DifferentGrids(t *testing.T) {
+	grid, _ := NewGrid("test", 1, 1, 50, 50)
+	location, _ := grid.NewLocation(2, 2)
+
+	_, err := location.DistanceTo(MinLocation())
+	assert.ErrorIs(t, err, ErrDifferentGrids)
+}
+
+func Test_Location_Equals_ComparesGrids(t *testing.T) {
+	grid, _ := NewGrid("north", 1, 1, 10, 10)
+	location, _ := grid.NewLocation(2, 2)
+	sameCell, _ := NewLocation(2, 2)
+
+	assert.False(t, location.Equals(sameCell))
+	assert.True(t, location.Equals(location))
+}
+
+func Test_NewGrid_RequiresZone(t *testing.T) {
+	_, err := NewGrid("", 1, 1, 10, 10)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func Test_Zones_RestoresLocationOnZoneGrid(t *testing.T) {
+	north, _ := NewGrid("north", 1, 1, 50, 30)
+	zones, err := NewZones(north, DefaultGrid())
+	assert.NoError(t, err)
+
+	location, err := zones.NewLocation("north", 40, 25)
+	assert.NoError(t, err)
+	assert.True(t, location.Grid().Equals(north))
+
+	_, err = zones.NewLocation(DefaultZone, 40, 25)
+	assert.ErrorIs(t, err, errs.ErrValueIsOutOfRange)
+
+	// Записи без зоны лежат на основной карте
+	location, err = zones.NewLocation("", 40, 25)
+	assert.NoError(t, err)
+	assert.True(t, location.Grid().Equals(north))
+
+	_, err = zones.NewLocation("south", 1, 1)
+	assert.ErrorIs(t, err, ErrUnknownZone)
+}
+
+func Test_NewZones_Invalid(t *testing.T) {
+	north, _ := NewGrid("north", 1, 1, 50, 30)
+
+	_, err := NewZones(Grid{})
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = NewZones(north, north)
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
@@ -1,35 +1,15 @@
 package kernel
 
-import (
-	"delivery/internal/pkg/errs"
-	"fmt"
-	"math"
-	"math/rand"
-)
-
-const (
-	minX int = 1
-	maxX int = 10
-	minY int = 1
-	maxY int = 10
-)
-
+// Location - клетка на карте. Точка помнит свою карту и проверяется по ее границам
 type Location struct {
-	x int
-	y int
+	x    int
+	y    int
+	grid Grid
 }
 
+// NewLocation создает точку на исторической карте 10x10
 func NewLocation(x, y int) (Location, error) {
-	if x < minX || x > maxX {
-		return Location{}, errs.NewValueIsOutOfRangeError("x", x, minX, maxX)
-	}
-	if y < minY || y > maxY {
-		return Location{}, errs.NewValueIsOutOfRangeError("y", y, minY, maxY)
-	}
-	return Location{
-		x: x,
-		y: y,
-	}, nil
+	return DefaultGrid().NewLocation(x, y)
 }
 
 func (l Location) X() int {
@@ -40,55 +20,46 @@ func (l Location) Y() int {
 	return l.y
 }
 
+func (l Location) Grid() Grid {
+	return l.grid
+}
+
 func (l Location) Equals(other Location) bool {
-	return l.x == other.x && l.y == other.y
+	return l.x == other.x && l.y == other.y && l.grid.Equals(other.grid)
 }
 
+// DistanceTo - длина кратчайшего пути до other в обход перекрытых клеток.
+// На карте без препятствий совпадает с манхэттенским расстоянием
 func (l Location) DistanceTo(other Location) (int, error) {
-	if err := l.IsValid(); err != nil {
-		return 0, fmt.Errorf("invalid origin location: %w", err)
-	}
-	if err := other.IsValid(); err != nil {
-		return 0, fmt.Errorf("invalid target location: %w", err)
+	route, err := l.grid.Route(l, other)
+	if err != nil {
+		return 0, err
 	}
+	return len(route), nil
+}
 
-	dx := int(math.Abs(float64(l.x - other.x)))
-	dy := int(math.Abs(float64(l.y - other.y)))
-	return dx + dy, nil
+// RouteTo - клетки, через которые нужно проехать до other, без текущей и с конечной
+func (l Location) RouteTo(other Location) ([]Location, error) {
+	return l.grid.Route(l, other)
 }
 
 func (l Location) IsValid() error {
-	if l.x < minX || l.x > maxX {
-		return errs.NewValueIsOutOfRangeError("x", l.x, minX, maxX)
-	}
-	if l.y < minY || l.y > maxY {
-		return errs.NewValueIsOutOfRangeError("y", l.y, minY, maxY)
-	}
-	return nil
+	return l.grid.validate(l.x, l.y)
 }
 
 func CreateRandom() (Location, error) {
-	x := rand.Intn(maxX) + 1
-	y := rand.Intn(maxY) + 1
-	location, err := NewLocation(x, y)
-	if err != nil {
-		panic("CreateRandom(): failed to create a valid Location: " + err.Error())
-	}
-	return location, err
+	return CreateRandomFrom(globalRandomSource{})
+}
+
+// CreateRandomFrom выбирает случайную точку на исторической карте 10x10
+func CreateRandomFrom(source RandomSource) (Location, error) {
+	return DefaultGrid().CreateRandom(source)
 }
 
 func MinLocation() Location {
-	location, err := NewLocation(minX, minY)
-	if err != nil {
-		panic("invalid min location configuration")
-	}
-	return location
+	return DefaultGrid().MinLocation()
 }
 
 func MaxLocation() Location {
-	location, err := NewLocation(maxX, maxY)
-	if err != nil {
-		panic("invalid max location configuration")
-	}
-	return location
+	return DefaultGrid().MaxLocation()
 }
@@ -87,3 +87,15 @@ func Test_Location_CreateRandom(t *testing.T) {
 	assert.GreaterOrEqual(t, randomLocation.Y(), 1)
 	assert.LessOrEqual(t, randomLocation.Y(), 10)
 }
+
+func Test_Location_CreateRandomFrom_IsReproducible(t *testing.T) {
+	// Steps
+	first, err := CreateRandomFrom(NewRandomSource(42))
+	assert.NoError(t, err)
+	second, err := CreateRandomFrom(NewRandomSource(42))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.True(t, first.Equals(second))
+	assert.NoError(t, first.IsValid())
+}
@@ -0,0 +1,35 @@
+package kernel
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// RandomSource - источник случайных чисел для доменных правил. В тестах подменяется детерминированным
+type RandomSource interface {
+	// Intn возвращает число из [0, n)
+	Intn(n int) int
+}
+
+// NewRandomSource создает источник с заданным seed. Им можно пользоваться из нескольких горутин
+func NewRandomSource(seed int64) RandomSource {
+	return &lockedRandomSource{rnd: rand.New(rand.NewSource(seed))}
+}
+
+type lockedRandomSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func (s *lockedRandomSource) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Intn(n)
+}
+
+// globalRandomSource - общий генератор math/rand
+type globalRandomSource struct{}
+
+func (globalRandomSource) Intn(n int) int {
+	return rand.Intn(n)
+}
@@ -0,0 +1,177 @@
+package kernel
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+var ErrRouteNotFound = errors.New("route between locations was not found")
+
+// Route ищет кратчайший путь между клетками в обход перекрытых (A*, ходы по горизонтали и вертикали).
+// Путь не включает from и включает to. Сами from и to считаются проезжими, даже если перекрыты:
+// курьер может стоять у реки, а заказ - ждать на краю пешеходной зоны
+func (g Grid) Route(from, to Location) ([]Location, error) {
+	if err := from.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid origin location: %w", err)
+	}
+	if err := to.IsValid(); err != nil {
+		return nil, fmt.Errorf("invalid target location: %w", err)
+	}
+	if !g.Equals(from.grid) || !g.Equals(to.grid) {
+		return nil, ErrDifferentGrids
+	}
+
+	// Без препятствий любой путь "сначала по x, потом по y" кратчайший
+	if len(g.blocked) == 0 {
+		return g.straightRoute(from, to)
+	}
+	return g.aStarRoute(from, to)
+}
+
+func (g Grid) straightRoute(from, to Location) ([]Location, error) {
+	route := make([]Location, 0, manhattan(from.x, from.y, to.x, to.y))
+	x, y := from.x, from.y
+	for x != to.x || y != to.y {
+		switch {
+		case x < to.x:
+			x++
+		case x > to.x:
+			x--
+		case y < to.y:
+			y++
+		default:
+			y--
+		}
+		location, err := g.NewLocation(x, y)
+		if err != nil {
+			return nil, err
+		}
+		route = append(route, location)
+	}
+	return route, nil
+}
+
+func (g Grid) aStarRoute(from, to Location) ([]Location, error) {
+	start := Cell{X: from.x, Y: from.y}
+	goal := Cell{X: to.x, Y: to.y}
+
+	cameFrom := make(map[Cell]Cell)
+	cost := map[Cell]int{start: 0}
+	open := &routeQueue{}
+	heap.Push(open, routeNode{cell: start, priority: manhattan(start.X, start.Y, goal.X, goal.Y)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(routeNode)
+		if current.cell == goal {
+			return g.restoreRoute(cameFrom, start, goal)
+		}
+		// В очереди могут остаться устаревшие записи о клетке, до которой уже нашли путь короче
+		if current.cost > cost[current.cell] {
+			continue
+		}
+
+		for _, next := range g.neighbours(current.cell) {
+			if next != goal && g.IsBlocked(next.X, next.Y) {
+				continue
+			}
+			nextCost := cost[current.cell] + 1
+			if known, ok := cost[next]; ok && known <= nextCost {
+				continue
+			}
+			cost[next] = nextCost
+			cameFrom[next] = current.cell
+			heap.Push(open, routeNode{
+				cell:     next,
+				cost:     nextCost,
+				priority: nextCost + manhattan(next.X, next.Y, goal.X, goal.Y),
+				order:    open.pushed,
+			})
+		}
+	}
+	return nil, ErrRouteNotFound
+}
+
+func (g Grid) restoreRoute(cameFrom map[Cell]Cell, start, goal Cell) ([]Location, error) {
+	cells := make([]Cell, 0)
+	for cell := goal; cell != start; cell = cameFrom[cell] {
+		cells = append(cells, cell)
+	}
+
+	route := make([]Location, 0, len(cells))
+	for i := len(cells) - 1; i >= 0; i-- {
+		location, err := g.NewLocation(cells[i].X, cells[i].Y)
+		if err != nil {
+			return nil, err
+		}
+		route = append(route, location)
+	}
+	return route, nil
+}
+
+// neighbours возвращает соседние клетки в пределах карты. Порядок фиксирован, чтобы маршрут был воспроизводимым
+func (g Grid) neighbours(cell Cell) []Cell {
+	candidates := []Cell{
+		{X: cell.X + 1, Y: cell.Y},
+		{X: cell.X - 1, Y: cell.Y},
+		{X: cell.X, Y: cell.Y + 1},
+		{X: cell.X, Y: cell.Y - 1},
+	}
+	result := make([]Cell, 0, len(candidates))
+	for _, candidate := range candidates {
+		if g.validate(candidate.X, candidate.Y) == nil {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+func manhattan(x1, y1, x2, y2 int) int {
+	return abs(x1-x2) + abs(y1-y2)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+type routeNode struct {
+	cell     Cell
+	cost     int
+	priority int
+	order    int
+}
+
+// routeQueue - очередь с приоритетом для A*: сначала меньшая оценка пути, при равенстве - добавленная раньше
+type routeQueue struct {
+	nodes  []routeNode
+	pushed int
+}
+
+func (q *routeQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *routeQueue) Less(i, j int) bool {
+	if q.nodes[i].priority != q.nodes[j].priority {
+		return q.nodes[i].priority < q.nodes[j].priority
+	}
+	return q.nodes[i].order < q.nodes[j].order
+}
+
+func (q *routeQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *routeQueue) Push(x any) {
+	q.nodes = append(q.nodes, x.(routeNode))
+	q.pushed++
+}
+
+func (q *routeQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
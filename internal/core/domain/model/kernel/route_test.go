@@ -0,0 +1,99 @@
+package kernel
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// riverGrid - карта 5x5, которую по x = 3 пересекает река с единственным мостом в (3, 5)
+func riverGrid(t *testing.T) Grid {
+	grid, err := NewGrid("test", 1, 1, 5, 5)
+	assert.NoError(t, err)
+	grid, err = grid.WithBlockedCells(Cell{3, 1}, Cell{3, 2}, Cell{3, 3}, Cell{3, 4})
+	assert.NoError(t, err)
+	return grid
+}
+
+func Test_Route_WithoutObstacles_GoesByXThenY(t *testing.T) {
+	from, _ := NewLocation(1, 1)
+	to, _ := NewLocation(3, 2)
+
+	route, err := from.RouteTo(to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{2, 1}, {3, 1}, {3, 2}}, cells(route))
+}
+
+func Test_Route_GoesAroundBlockedCells(t *testing.T) {
+	grid := riverGrid(t)
+	from, _ := grid.NewLocation(1, 1)
+	to, _ := grid.NewLocation(5, 1)
+
+	route, err := from.RouteTo(to)
+	assert.NoError(t, err)
+	for _, location := range route {
+		assert.False(t, grid.IsBlocked(location.X(), location.Y()))
+	}
+	assert.Contains(t, cells(route), [2]int{3, 5})
+	assert.True(t, route[len(route)-1].Equals(to))
+
+	// Напрямую 4 клетки, через мост - 12
+	distance, err := from.DistanceTo(to)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, distance)
+}
+
+func Test_Route_NotFound(t *testing.T) {
+	grid, _ := riverGrid(t).WithBlockedCells(Cell{3, 5})
+	from, _ := grid.NewLocation(1, 1)
+	to, _ := grid.NewLocation(5, 1)
+
+	_, err := from.DistanceTo(to)
+
+	assert.ErrorIs(t, err, ErrRouteNotFound)
+}
+
+func Test_Route_ToBlockedCell(t *testing.T) {
+	grid := riverGrid(t)
+	from, _ := grid.NewLocation(1, 2)
+	to, _ := grid.NewLocation(3, 2)
+
+	distance, err := from.DistanceTo(to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, distance)
+}
+
+func Test_Grid_WithBlockedCells(t *testing.T) {
+	grid := riverGrid(t)
+	sameGrid, _ := NewGrid("test", 1, 1, 5, 5)
+	sameGrid, _ = sameGrid.WithBlockedCells(Cell{3, 4}, Cell{3, 3}, Cell{3, 2}, Cell{3, 1})
+
+	assert.True(t, grid.IsBlocked(3, 2))
+	assert.False(t, grid.IsBlocked(3, 5))
+	assert.Len(t, grid.BlockedCells(), 4)
+	assert.True(t, grid.Equals(sameGrid))
+	assert.False(t, grid.Equals(DefaultGrid()))
+
+	_, err := grid.WithBlockedCells(Cell{6, 1})
+	assert.Error(t, err)
+}
+
+func Test_Grid_CreateRandom_SkipsBlockedCells(t *testing.T) {
+	grid := riverGrid(t)
+	source := NewRandomSource(42)
+
+	for i := 0; i < 100; i++ {
+		location, err := grid.CreateRandom(source)
+		assert.NoError(t, err)
+		assert.False(t, grid.IsBlocked(location.X(), location.Y()))
+	}
+}
+
+func cells(route []Location) [][2]int {
+	result := make([][2]int, len(route))
+	for i, location := range route {
+		result[i] = [2]int{location.X(), location.Y()}
+	}
+	return result
+}
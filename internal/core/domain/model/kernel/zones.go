@@ -0,0 +1,57 @@
+package kernel
+
+import (
+	"delivery/internal/pkg/errs"
+	"errors"
+)
+
+var ErrUnknownZone = errors.New("zone is not configured")
+
+// Zones - карты зон доставки сервиса, по имени зоны точка восстанавливается на своей карте.
+// Первая карта - основная: на ней создаются новые точки и лежат записи, сохраненные до появления зон
+type Zones struct {
+	primary Grid
+	grids   map[string]Grid
+}
+
+func NewZones(primary Grid, others ...Grid) (Zones, error) {
+	if primary.IsEmpty() {
+		return Zones{}, errs.NewValueIsRequiredError("primary")
+	}
+	grids := make(map[string]Grid, len(others)+1)
+	for _, grid := range append([]Grid{primary}, others...) {
+		if grid.IsEmpty() {
+			return Zones{}, errs.NewValueIsRequiredError("grid")
+		}
+		if _, ok := grids[grid.Zone()]; ok {
+			return Zones{}, errs.NewValueIsInvalidError("zone")
+		}
+		grids[grid.Zone()] = grid
+	}
+	return Zones{primary: primary, grids: grids}, nil
+}
+
+func (z Zones) Primary() Grid {
+	return z.primary
+}
+
+// Grid возвращает карту зоны. Пустое имя - запись без зоны, она относится к основной карте
+func (z Zones) Grid(zone string) (Grid, error) {
+	if zone == "" {
+		return z.primary, nil
+	}
+	grid, ok := z.grids[zone]
+	if !ok {
+		return Grid{}, ErrUnknownZone
+	}
+	return grid, nil
+}
+
+// NewLocation восстанавливает точку на карте зоны
+func (z Zones) NewLocation(zone string, x, y int) (Location, error) {
+	grid, err := z.Grid(zone)
+	if err != nil {
+		return Location{}, err
+	}
+	return grid.NewLocation(x, y)
+}
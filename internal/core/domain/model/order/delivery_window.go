@@ -0,0 +1,75 @@
+package order
+
+import (
+	"delivery/internal/pkg/errs"
+	"time"
+)
+
+// DeliveryWindow - интервал времени, в который клиент ждет заказ
+type DeliveryWindow struct {
+	from time.Time
+	to   time.Time
+
+	isSet bool
+}
+
+func NewDeliveryWindow(from time.Time, to time.Time) (DeliveryWindow, error) {
+	if from.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("from")
+	}
+	if to.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("to")
+	}
+	if !to.After(from) {
+		return DeliveryWindow{}, errs.NewValueIsInvalidError("to")
+	}
+
+	return DeliveryWindow{
+		from:  from,
+		to:    to,
+		isSet: true,
+	}, nil
+}
+
+func (w DeliveryWindow) From() time.Time {
+	return w.from
+}
+
+func (w DeliveryWindow) To() time.Time {
+	return w.to
+}
+
+func (w DeliveryWindow) IsEmpty() bool {
+	return !w.isSet
+}
+
+// HasPassed - окно уже закрылось к моменту now
+func (w DeliveryWindow) HasPassed(now time.Time) bool {
+	if w.IsEmpty() {
+		return false
+	}
+	return now.After(w.to)
+}
+
+// CanBeMet - заказ, доставленный в момент arrival, успеет в окно. Приехать раньше можно: курьер подождет
+func (w DeliveryWindow) CanBeMet(arrival time.Time) bool {
+	if w.IsEmpty() {
+		return true
+	}
+	return !arrival.After(w.to)
+}
+
+// ClosesBefore - окно закрывается раньше другого. Окно без ограничений закрывается позже любого
+func (w DeliveryWindow) ClosesBefore(other DeliveryWindow) bool {
+	if w.IsEmpty() {
+		return false
+	}
+	if other.IsEmpty() {
+		return true
+	}
+	return w.to.Before(other.to)
+}
+
+func (w DeliveryWindow) Equals(other DeliveryWindow) bool {
+	return w.isSet == other.isSet && w.from.Equal(other.from) && w.to.Equal(other.to)
+}
@@ -0,0 +1,74 @@
+package order
+
+import (
+	"delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// Item - товар из корзины, который курьер передает клиенту
+type Item struct {
+	id       uuid.UUID
+	goodID   uuid.UUID
+	title    string
+	price    float64
+	quantity int
+}
+
+func NewItem(id uuid.UUID, goodID uuid.UUID, title string, price float64, quantity int) (Item, error) {
+	if id == uuid.Nil {
+		return Item{}, errs.NewValueIsRequiredError("id")
+	}
+	if goodID == uuid.Nil {
+		return Item{}, errs.NewValueIsRequiredError("goodID")
+	}
+	if title == "" {
+		return Item{}, errs.NewValueIsRequiredError("title")
+	}
+	if price < 0 {
+		return Item{}, errs.NewValueIsInvalidError("price")
+	}
+	if quantity <= 0 {
+		return Item{}, errs.NewValueIsRequiredError("quantity")
+	}
+	return Item{
+		id:       id,
+		goodID:   goodID,
+		title:    title,
+		price:    price,
+		quantity: quantity,
+	}, nil
+}
+
+func (i Item) ID() uuid.UUID {
+	return i.id
+}
+
+func (i Item) GoodID() uuid.UUID {
+	return i.goodID
+}
+
+func (i Item) Title() string {
+	return i.title
+}
+
+func (i Item) Price() float64 {
+	return i.price
+}
+
+func (i Item) Quantity() int {
+	return i.quantity
+}
+
+func (i Item) Equals(other Item) bool {
+	return i.id == other.id
+}
+
+func RestoreItem(id uuid.UUID, goodID uuid.UUID, title string, price float64, quantity int) Item {
+	return Item{
+		id:       id,
+		goodID:   goodID,
+		title:    title,
+		price:    price,
+		quantity: quantity,
+	}
+}
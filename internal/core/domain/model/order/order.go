@@ -6,14 +6,23 @@ import (
 	"delivery/internal/pkg/errs"
 	"errors"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Order struct {
 	*ddd.BaseAggregate[uuid.UUID]
-	courierId *uuid.UUID
-	location  kernel.Location
-	volume    int
-	status    Status
+	courierId    *uuid.UUID
+	location     kernel.Location
+	coordinate   kernel.GeoCoordinate
+	volume       int
+	status       Status
+	cancelReason string
+	createdAt    time.Time
+
+	address        kernel.Address
+	items          []Item
+	deliveryWindow DeliveryWindow
+	late           bool
 }
 
 func NewOrder(orderID uuid.UUID, location kernel.Location, volume int) (*Order, error) {
@@ -26,12 +35,79 @@ func NewOrder(orderID uuid.UUID, location kernel.Location, volume int) (*Order,
 	if volume <= 0 {
 		return nil, errs.NewValueIsRequiredError("volume")
 	}
-	return &Order{
+	order := &Order{
 		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](orderID),
 		location:      location,
 		volume:        volume,
 		status:        StatusCreated,
-	}, nil
+		createdAt:     time.Now().UTC(),
+		items:         make([]Item, 0),
+	}
+
+	// Публикуем доменное событие
+	order.BaseAggregate.RaiseDomainEvent(NewCreatedDomainEvent(order))
+
+	return order, nil
+}
+
+// SetAddress задает полный адрес доставки. Менять его можно, пока заказ не назначен курьеру
+func (o *Order) SetAddress(address kernel.Address) error {
+	if o.status != StatusCreated {
+		return errors.New("address can be set only for created orders")
+	}
+	o.address = address
+	return nil
+}
+
+// SetCoordinate задает географические координаты точки доставки, если их вернул геокодер
+func (o *Order) SetCoordinate(coordinate kernel.GeoCoordinate) error {
+	if coordinate.IsEmpty() {
+		return errs.NewValueIsRequiredError("coordinate")
+	}
+	if o.status != StatusCreated {
+		return errors.New("coordinate can be set only for created orders")
+	}
+	o.coordinate = coordinate
+	return nil
+}
+
+// AddItem добавляет в заказ товар из корзины
+func (o *Order) AddItem(item Item) error {
+	if o.status != StatusCreated {
+		return errors.New("items can be added only to created orders")
+	}
+	for _, existing := range o.items {
+		if existing.Equals(item) {
+			return errs.NewValueIsInvalidError("item")
+		}
+	}
+	o.items = append(o.items, item)
+	return nil
+}
+
+// SetDeliveryWindow задает окно доставки. Менять его можно, пока заказ не назначен курьеру
+func (o *Order) SetDeliveryWindow(window DeliveryWindow) error {
+	if o.status != StatusCreated {
+		return errors.New("delivery window can be set only for created orders")
+	}
+	o.deliveryWindow = window
+	return nil
+}
+
+// MarkLate помечает опоздавшим недоставленный заказ, окно доставки которого уже закрылось.
+// Возвращает true, если признак изменился
+func (o *Order) MarkLate(now time.Time) bool {
+	if o.late {
+		return false
+	}
+	if o.status != StatusCreated && o.status != StatusAssigned {
+		return false
+	}
+	if !o.deliveryWindow.HasPassed(now) {
+		return false
+	}
+	o.late = true
+	return true
 }
 
 func (o *Order) AssignCourier(courierId uuid.UUID) error {
@@ -40,6 +116,10 @@ func (o *Order) AssignCourier(courierId uuid.UUID) error {
 	}
 	o.courierId = &courierId
 	o.status = StatusAssigned
+
+	// Публикуем доменное событие
+	o.BaseAggregate.RaiseDomainEvent(NewAssignedDomainEvent(o))
+
 	return nil
 }
 
@@ -55,6 +135,22 @@ func (o *Order) Complete() error {
 	return nil
 }
 
+func (o *Order) Cancel(reason string) error {
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+	if o.status != StatusCreated && o.status != StatusAssigned {
+		return errors.New("only created or assigned orders can be cancelled")
+	}
+	o.status = StatusCancelled
+	o.cancelReason = reason
+
+	// Публикуем доменное событие
+	o.BaseAggregate.RaiseDomainEvent(NewCancelledDomainEvent(o))
+
+	return nil
+}
+
 func (o *Order) Equals(other *Order) bool {
 	if other == nil {
 		return false
@@ -74,6 +170,11 @@ func (o *Order) Location() kernel.Location {
 	return o.location
 }
 
+// Coordinate - географические координаты точки доставки, пустые, если геокодер их не знает
+func (o *Order) Coordinate() kernel.GeoCoordinate {
+	return o.coordinate
+}
+
 func (o *Order) Volume() int {
 	return o.volume
 }
@@ -82,12 +183,52 @@ func (o *Order) Status() Status {
 	return o.status
 }
 
-func RestoreOrder(id uuid.UUID, courierID *uuid.UUID, location kernel.Location, volume int, status Status) *Order {
+func (o *Order) CancelReason() string {
+	return o.cancelReason
+}
+
+func (o *Order) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+func (o *Order) Address() kernel.Address {
+	return o.address
+}
+
+func (o *Order) Items() []Item {
+	res := make([]Item, len(o.items))
+	copy(res, o.items)
+	return res
+}
+
+func (o *Order) DeliveryWindow() DeliveryWindow {
+	return o.deliveryWindow
+}
+
+func (o *Order) IsLate() bool {
+	return o.late
+}
+
+func RestoreOrder(id uuid.UUID, courierID *uuid.UUID, location kernel.Location, coordinate kernel.GeoCoordinate,
+	volume int, status Status,
+	cancelReason string, createdAt time.Time, address kernel.Address, items []Item, deliveryWindow DeliveryWindow,
+	late bool) *Order {
+	if items == nil {
+		items = make([]Item, 0)
+	}
 	return &Order{
 		BaseAggregate: ddd.NewBaseAggregate(id),
 		courierId:     courierID,
 		location:      location,
+		coordinate:    coordinate,
 		volume:        volume,
 		status:        status,
+		cancelReason:  cancelReason,
+		createdAt:     createdAt,
+
+		address:        address,
+		items:          items,
+		deliveryWindow: deliveryWindow,
+		late:           late,
 	}
 }
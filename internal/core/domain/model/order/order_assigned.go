@@ -0,0 +1,54 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"reflect"
+)
+
+var _ ddd.AggregateDomainEvent = &AssignedDomainEvent{}
+
+type AssignedDomainEvent struct {
+	// base
+	ID   uuid.UUID
+	Name string
+
+	// payload
+	OrderID     uuid.UUID
+	OrderStatus string
+	CourierID   uuid.UUID
+
+	isSet bool
+}
+
+func (e AssignedDomainEvent) GetID() uuid.UUID { return e.ID }
+
+func (e AssignedDomainEvent) GetAggregateID() uuid.UUID { return e.OrderID }
+
+func (e AssignedDomainEvent) GetName() string {
+	return e.Name
+}
+
+func NewAssignedDomainEvent(aggregate *Order) ddd.DomainEvent {
+	assignedDomainEvent := AssignedDomainEvent{
+		ID: uuid.New(),
+
+		OrderID:     aggregate.Id(),
+		OrderStatus: aggregate.Status().String(),
+		CourierID:   *aggregate.CourierId(),
+
+		isSet: true,
+	}
+	assignedDomainEvent.Name = reflect.TypeOf(assignedDomainEvent).Name()
+	return &assignedDomainEvent
+}
+
+func NewEmptyAssignedDomainEvent() ddd.DomainEvent {
+	assignedDomainEvent := AssignedDomainEvent{}
+	assignedDomainEvent.Name = reflect.TypeOf(assignedDomainEvent).Name()
+	return &assignedDomainEvent
+}
+
+func (e AssignedDomainEvent) IsEmpty() bool {
+	return !e.isSet
+}
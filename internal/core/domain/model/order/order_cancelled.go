@@ -0,0 +1,54 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"reflect"
+)
+
+var _ ddd.AggregateDomainEvent = &CancelledDomainEvent{}
+
+type CancelledDomainEvent struct {
+	// base
+	ID   uuid.UUID
+	Name string
+
+	// payload
+	OrderID     uuid.UUID
+	OrderStatus string
+	Reason      string
+
+	isSet bool
+}
+
+func (e CancelledDomainEvent) GetID() uuid.UUID { return e.ID }
+
+func (e CancelledDomainEvent) GetAggregateID() uuid.UUID { return e.OrderID }
+
+func (e CancelledDomainEvent) GetName() string {
+	return e.Name
+}
+
+func NewCancelledDomainEvent(aggregate *Order) ddd.DomainEvent {
+	cancelledDomainEvent := CancelledDomainEvent{
+		ID: uuid.New(),
+
+		OrderID:     aggregate.Id(),
+		OrderStatus: aggregate.Status().String(),
+		Reason:      aggregate.CancelReason(),
+
+		isSet: true,
+	}
+	cancelledDomainEvent.Name = reflect.TypeOf(cancelledDomainEvent).Name()
+	return &cancelledDomainEvent
+}
+
+func NewEmptyCancelledDomainEvent() ddd.DomainEvent {
+	cancelledDomainEvent := CancelledDomainEvent{}
+	cancelledDomainEvent.Name = reflect.TypeOf(cancelledDomainEvent).Name()
+	return &cancelledDomainEvent
+}
+
+func (e CancelledDomainEvent) IsEmpty() bool {
+	return !e.isSet
+}
@@ -6,7 +6,7 @@ import (
 	"reflect"
 )
 
-var _ ddd.DomainEvent = &CompletedDomainEvent{}
+var _ ddd.AggregateDomainEvent = &CompletedDomainEvent{}
 
 type CompletedDomainEvent struct {
 	// base
@@ -22,6 +22,8 @@ type CompletedDomainEvent struct {
 
 func (e CompletedDomainEvent) GetID() uuid.UUID { return e.ID }
 
+func (e CompletedDomainEvent) GetAggregateID() uuid.UUID { return e.OrderID }
+
 func (e CompletedDomainEvent) GetName() string {
 	return e.Name
 }
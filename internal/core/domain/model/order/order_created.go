@@ -0,0 +1,52 @@
+package order
+
+import (
+	"delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"reflect"
+)
+
+var _ ddd.AggregateDomainEvent = &CreatedDomainEvent{}
+
+type CreatedDomainEvent struct {
+	// base
+	ID   uuid.UUID
+	Name string
+
+	// payload
+	OrderID     uuid.UUID
+	OrderStatus string
+
+	isSet bool
+}
+
+func (e CreatedDomainEvent) GetID() uuid.UUID { return e.ID }
+
+func (e CreatedDomainEvent) GetAggregateID() uuid.UUID { return e.OrderID }
+
+func (e CreatedDomainEvent) GetName() string {
+	return e.Name
+}
+
+func NewCreatedDomainEvent(aggregate *Order) ddd.DomainEvent {
+	createdDomainEvent := CreatedDomainEvent{
+		ID: uuid.New(),
+
+		OrderID:     aggregate.Id(),
+		OrderStatus: aggregate.Status().String(),
+
+		isSet: true,
+	}
+	createdDomainEvent.Name = reflect.TypeOf(createdDomainEvent).Name()
+	return &createdDomainEvent
+}
+
+func NewEmptyCreatedDomainEvent() ddd.DomainEvent {
+	createdDomainEvent := CreatedDomainEvent{}
+	createdDomainEvent.Name = reflect.TypeOf(createdDomainEvent).Name()
+	return &createdDomainEvent
+}
+
+func (e CreatedDomainEvent) IsEmpty() bool {
+	return !e.isSet
+}
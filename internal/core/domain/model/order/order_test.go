@@ -5,6 +5,7 @@ import (
 	"delivery/internal/core/domain/model/order"
 	"github.com/google/uuid"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,6 +38,8 @@ func Test_NewOrder_Success(t *testing.T) {
 	assert.Equal(t, testVolume, o.Volume())
 	assert.Equal(t, order.StatusCreated, o.Status())
 	assert.Nil(t, o.CourierId())
+	assert.Len(t, o.GetDomainEvents(), 1)
+	assert.IsType(t, &order.CreatedDomainEvent{}, o.GetDomainEvents()[0])
 }
 
 func Test_NewOrder_EmptyId(t *testing.T) {
@@ -78,6 +81,11 @@ func Test_Order_AssignCourier_Success(t *testing.T) {
 	assert.NotNil(t, o.CourierId())
 	assert.Equal(t, courierID, *o.CourierId())
 	assert.Equal(t, order.StatusAssigned, o.Status())
+	assert.Len(t, o.GetDomainEvents(), 2)
+	assigned, ok := o.GetDomainEvents()[1].(*order.AssignedDomainEvent)
+	assert.True(t, ok)
+	assert.Equal(t, courierID, assigned.CourierID)
+	assert.Equal(t, order.StatusAssigned.String(), assigned.OrderStatus)
 }
 
 func Test_Order_AssignCourier_WrongStatus(t *testing.T) {
@@ -110,6 +118,161 @@ func Test_Order_Complete_WrongStatus(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_Order_Cancel_Created(t *testing.T) {
+	setup()
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	err := o.Cancel("Клиент передумал")
+
+	assert.NoError(t, err)
+	assert.Equal(t, order.StatusCancelled, o.Status())
+	assert.Equal(t, "Клиент передумал", o.CancelReason())
+	events := o.GetDomainEvents()
+	assert.IsType(t, &order.CancelledDomainEvent{}, events[len(events)-1])
+}
+
+func Test_Order_Cancel_Assigned(t *testing.T) {
+	setup()
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	_ = o.AssignCourier(uuid.New())
+	err := o.Cancel("Клиент передумал")
+
+	assert.NoError(t, err)
+	assert.Equal(t, order.StatusCancelled, o.Status())
+}
+
+func Test_Order_Cancel_EmptyReason(t *testing.T) {
+	setup()
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	err := o.Cancel("")
+
+	assert.Error(t, err)
+	assert.Equal(t, order.StatusCreated, o.Status())
+}
+
+func Test_Order_Cancel_WrongStatus(t *testing.T) {
+	setup()
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	_ = o.AssignCourier(uuid.New())
+	_ = o.Complete()
+	err := o.Cancel("Клиент передумал")
+	assert.Error(t, err)
+
+	o, _ = order.NewOrder(testOrderID, testLocation, testVolume)
+	_ = o.Cancel("Клиент передумал")
+	err = o.Cancel("Клиент передумал")
+	assert.Error(t, err)
+}
+
+func Test_Order_SetAddress(t *testing.T) {
+	setup()
+	address, _ := kernel.NewAddress("Россия", "Москва", "Тверская", "1", "10")
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	err := o.SetAddress(address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, address, o.Address())
+}
+
+func Test_Order_AddItem(t *testing.T) {
+	setup()
+	item, err := order.NewItem(uuid.New(), uuid.New(), "Пицца", 500, 2)
+	assert.NoError(t, err)
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	assert.NoError(t, o.AddItem(item))
+	assert.Error(t, o.AddItem(item))
+	assert.Equal(t, []order.Item{item}, o.Items())
+
+	_ = o.AssignCourier(uuid.New())
+	other, _ := order.NewItem(uuid.New(), uuid.New(), "Сок", 100, 1)
+	assert.Error(t, o.AddItem(other))
+}
+
+func Test_NewItem_Invalid(t *testing.T) {
+	_, err := order.NewItem(uuid.Nil, uuid.New(), "Пицца", 500, 1)
+	assert.Error(t, err)
+	_, err = order.NewItem(uuid.New(), uuid.New(), "", 500, 1)
+	assert.Error(t, err)
+	_, err = order.NewItem(uuid.New(), uuid.New(), "Пицца", -1, 1)
+	assert.Error(t, err)
+	_, err = order.NewItem(uuid.New(), uuid.New(), "Пицца", 500, 0)
+	assert.Error(t, err)
+}
+
+func Test_NewDeliveryWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	window, err := order.NewDeliveryWindow(now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, window.IsEmpty())
+	assert.Equal(t, now, window.From())
+	assert.Equal(t, now.Add(time.Hour), window.To())
+
+	_, err = order.NewDeliveryWindow(now, now)
+	assert.Error(t, err)
+	_, err = order.NewDeliveryWindow(time.Time{}, now)
+	assert.Error(t, err)
+}
+
+func Test_DeliveryWindow_CanBeMet(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	window, _ := order.NewDeliveryWindow(now, now.Add(time.Hour))
+	later, _ := order.NewDeliveryWindow(now, now.Add(2*time.Hour))
+
+	assert.True(t, window.CanBeMet(now.Add(-time.Minute)))
+	assert.True(t, window.CanBeMet(now.Add(time.Hour)))
+	assert.False(t, window.CanBeMet(now.Add(time.Hour+time.Second)))
+	assert.True(t, order.DeliveryWindow{}.CanBeMet(now.Add(24*time.Hour)))
+
+	assert.True(t, window.ClosesBefore(later))
+	assert.False(t, later.ClosesBefore(window))
+	assert.True(t, window.ClosesBefore(order.DeliveryWindow{}))
+	assert.False(t, order.DeliveryWindow{}.ClosesBefore(window))
+}
+
+func Test_Order_SetDeliveryWindow_WrongStatus(t *testing.T) {
+	setup()
+	now := time.Now()
+	window, _ := order.NewDeliveryWindow(now, now.Add(time.Hour))
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	_ = o.AssignCourier(uuid.New())
+	err := o.SetDeliveryWindow(window)
+
+	assert.Error(t, err)
+	assert.True(t, o.DeliveryWindow().IsEmpty())
+}
+
+func Test_Order_MarkLate(t *testing.T) {
+	setup()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	window, _ := order.NewDeliveryWindow(now, now.Add(time.Hour))
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+	assert.NoError(t, o.SetDeliveryWindow(window))
+
+	assert.False(t, o.MarkLate(now.Add(30*time.Minute)))
+	assert.False(t, o.IsLate())
+
+	assert.True(t, o.MarkLate(now.Add(2*time.Hour)))
+	assert.True(t, o.IsLate())
+	assert.False(t, o.MarkLate(now.Add(3*time.Hour)))
+}
+
+func Test_Order_MarkLate_NoWindow(t *testing.T) {
+	setup()
+
+	o, _ := order.NewOrder(testOrderID, testLocation, testVolume)
+
+	assert.False(t, o.MarkLate(time.Now().Add(24*time.Hour)))
+	assert.False(t, o.IsLate())
+}
+
 func Test_Order_Equals(t *testing.T) {
 	setup()
 
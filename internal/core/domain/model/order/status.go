@@ -5,6 +5,7 @@ const (
 	StatusCreated   Status = "Created"
 	StatusAssigned  Status = "Assigned"
 	StatusCompleted Status = "Completed"
+	StatusCancelled Status = "Cancelled"
 )
 
 type Status string
@@ -0,0 +1,101 @@
+package services
+
+import "math"
+
+// solveAssignment решает задачу о назначениях венгерским алгоритмом: каждой строке матрицы
+// стоимостей подбирает свой столбец так, чтобы сумма стоимостей была минимальной.
+// Возвращает номер столбца для каждой строки. Если строк больше, чем столбцов,
+// части строк столбца не достанется, для них возвращается -1
+func solveAssignment(cost [][]float64) []int {
+	rows := len(cost)
+	if rows == 0 {
+		return nil
+	}
+	cols := len(cost[0])
+	if cols == 0 {
+		result := make([]int, rows)
+		for i := range result {
+			result[i] = -1
+		}
+		return result
+	}
+
+	// Алгоритм требует, чтобы строк было не больше, чем столбцов
+	if rows > cols {
+		transposed := make([][]float64, cols)
+		for j := range transposed {
+			transposed[j] = make([]float64, rows)
+			for i := range cost {
+				transposed[j][i] = cost[i][j]
+			}
+		}
+		result := make([]int, rows)
+		for i := range result {
+			result[i] = -1
+		}
+		for j, i := range solveAssignment(transposed) {
+			result[i] = j
+		}
+		return result
+	}
+
+	// Потенциалы строк и столбцов, строка, занявшая столбец, и путь чередующейся цепочки.
+	// Нулевой столбец - фиктивный, с него начинается поиск для очередной строки
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	owner := make([]int, cols+1)
+	way := make([]int, cols+1)
+	for i := 1; i <= rows; i++ {
+		owner[0] = i
+		column := 0
+		minReduced := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for j := range minReduced {
+			minReduced[j] = math.Inf(1)
+		}
+		for {
+			used[column] = true
+			row, delta, next := owner[column], math.Inf(1), 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				reduced := cost[row-1][j-1] - u[row] - v[j]
+				if reduced < minReduced[j] {
+					minReduced[j] = reduced
+					way[j] = column
+				}
+				if minReduced[j] < delta {
+					delta = minReduced[j]
+					next = j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[owner[j]] += delta
+					v[j] -= delta
+				} else {
+					minReduced[j] -= delta
+				}
+			}
+			column = next
+			if owner[column] == 0 {
+				break
+			}
+		}
+		// Переворачиваем цепочку: каждая строка на пути переходит в следующий столбец
+		for column != 0 {
+			previous := way[column]
+			owner[column] = owner[previous]
+			column = previous
+		}
+	}
+
+	result := make([]int, rows)
+	for j := 1; j <= cols; j++ {
+		if owner[j] != 0 {
+			result[owner[j]-1] = j - 1
+		}
+	}
+	return result
+}
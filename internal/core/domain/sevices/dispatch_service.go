@@ -2,20 +2,48 @@ package services
 
 import (
 	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
 	orderpkg "delivery/internal/core/domain/model/order"
 	"delivery/internal/pkg/errs"
 	"errors"
-	"math"
+	"sort"
+	"time"
 )
 
 type DispatchService interface {
 	Dispatch(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error)
+	DispatchAll(orders []*orderpkg.Order, couriers []*courier.Courier) ([]Assignment, error)
 }
 
-type dispatchService struct{}
+// Assignment - заказ и курьер, которому он назначен
+type Assignment struct {
+	Order   *orderpkg.Order
+	Courier *courier.Courier
+}
+
+type dispatchService struct {
+	strategy  DispatchStrategy
+	navigator Navigator
+	now       func() time.Time
+}
 
 func NewDispatchService() DispatchService {
-	return &dispatchService{}
+	navigator := NewGridNavigator()
+	strategy, _ := NewFastestCourierStrategy(navigator)
+	return &dispatchService{strategy: strategy, navigator: navigator, now: time.Now}
+}
+
+// NewDispatchServiceWithStrategy создает сервис, который проверяет окно доставки по времени в пути
+// через navigator. Стратегии нужен тот же navigator, иначе они будут по-разному считать дорогу.
+// Стратегия выбирает курьера для одного заказа в Dispatch, DispatchAll сокращает суммарное время в пути
+func NewDispatchServiceWithStrategy(strategy DispatchStrategy, navigator Navigator) (DispatchService, error) {
+	if strategy == nil {
+		return nil, errs.NewValueIsRequiredError("strategy")
+	}
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &dispatchService{strategy: strategy, navigator: navigator, now: time.Now}, nil
 }
 
 var (
@@ -48,13 +76,161 @@ func (s *dispatchService) Dispatch(order *orderpkg.Order, couriers []*courier.Co
 	return bestCourier, nil
 }
 
+// DispatchAll распределяет сразу все заказы так, чтобы суммарное время в пути курьеров до заказов
+// было как можно меньше: пары заказ-курьер подбираются венгерским алгоритмом по матрице шагов.
+// Окно доставки - ограничение: сначала распределяются заказы, чье окно закрывается раньше, заказы
+// с одинаковым концом окна распределяются вместе. За раунд курьер получает не больше одного заказа,
+// следующий раунд раздает оставшиеся заказы курьерам, у которых осталось место.
+// Заказы, которые некому взять, остаются нераспределенными
+func (s *dispatchService) DispatchAll(orders []*orderpkg.Order, couriers []*courier.Courier) ([]Assignment, error) {
+	if len(orders) == 0 {
+		return nil, errs.NewValueIsRequiredError("orders")
+	}
+	if len(couriers) == 0 {
+		return nil, errs.NewValueIsRequiredError("couriers")
+	}
+
+	pending := make([]*orderpkg.Order, 0, len(orders))
+	for _, order := range orders {
+		if order == nil {
+			return nil, errs.NewValueIsRequiredError("order")
+		}
+		if order.Status() == orderpkg.StatusCreated {
+			pending = append(pending, order)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].DeliveryWindow().ClosesBefore(pending[j].DeliveryWindow())
+	})
+
+	var assignments []Assignment
+	for start := 0; start < len(pending); {
+		end := start + 1
+		for end < len(pending) && !pending[start].DeliveryWindow().ClosesBefore(pending[end].DeliveryWindow()) {
+			end++
+		}
+
+		group := pending[start:end]
+		for {
+			round, err := s.assignRound(group, couriers)
+			if err != nil {
+				return nil, err
+			}
+			if len(round) == 0 {
+				break
+			}
+			assignments = append(assignments, round...)
+		}
+		start = end
+	}
+
+	// Назначения возвращаются в порядке очереди заказов
+	position := make(map[*orderpkg.Order]int, len(pending))
+	for i, order := range pending {
+		position[order] = i
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return position[assignments[i].Order] < position[assignments[j].Order]
+	})
+	return assignments, nil
+}
+
+// assignRound назначает нераспределенным заказам группы по одному заказу на курьера так,
+// чтобы суммарное время в пути было минимальным
+func (s *dispatchService) assignRound(orders []*orderpkg.Order, couriers []*courier.Courier) ([]Assignment, error) {
+	var pending []*orderpkg.Order
+	for _, order := range orders {
+		if order.Status() == orderpkg.StatusCreated {
+			pending = append(pending, order)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	// Матрица шагов курьера до заказа. Пара, которая не годится, стоит дороже всех годных пар вместе,
+	// поэтому решение сначала набирает как можно больше годных пар, а уже потом сокращает время в пути
+	now := s.now()
+	steps := make([][]float64, len(pending))
+	feasible := make([][]bool, len(pending))
+	total, found := 0.0, false
+	for i, order := range pending {
+		steps[i] = make([]float64, len(couriers))
+		feasible[i] = make([]bool, len(couriers))
+		for j, c := range couriers {
+			ok, travel, err := s.pairSteps(order, c, now)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				steps[i][j], feasible[i][j] = travel, true
+				total += travel
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+	for i := range steps {
+		for j := range steps[i] {
+			if !feasible[i][j] {
+				steps[i][j] = total + 1
+			}
+		}
+	}
+
+	var assignments []Assignment
+	for i, j := range solveAssignment(steps) {
+		if j < 0 || !feasible[i][j] {
+			continue
+		}
+		order, c := pending[i], couriers[j]
+		if err := c.TakeOrder(order); err != nil {
+			return nil, err
+		}
+		if err := order.AssignCourier(c.ID()); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, Assignment{Order: order, Courier: c})
+	}
+	return assignments, nil
+}
+
+// pairSteps возвращает, сколько шагов курьеру ехать до заказа, если курьер может взять заказ
+// и успевает в окно доставки
+func (s *dispatchService) pairSteps(order *orderpkg.Order, c *courier.Courier, now time.Time) (bool, float64, error) {
+	canTake, err := c.CanTakeOrder(order)
+	if err != nil {
+		return false, 0, err
+	}
+	if !canTake {
+		return false, 0, nil
+	}
+	inTime, err := s.deliversInTime(order, c, now)
+	if err != nil || !inTime {
+		return false, 0, err
+	}
+	steps, err := s.navigator.StepsTo(c, order)
+	if errors.Is(err, kernel.ErrRouteNotFound) {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return true, steps, nil
+}
+
 func (s *dispatchService) findBestCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
 	availableCouriers, err := s.filterAvailableCouriers(order, couriers)
+	if err != nil {
+		return nil, err
+	}
 	if len(availableCouriers) == 0 {
 		return nil, ErrSuitableCourierWasNotFound
 	}
 
-	best, err := s.findFastestCourier(order, availableCouriers)
+	best, err := s.strategy.SelectCourier(order, availableCouriers)
 	if err != nil {
 		return nil, err
 	}
@@ -62,31 +238,38 @@ func (s *dispatchService) findBestCourier(order *orderpkg.Order, couriers []*cou
 }
 
 func (s *dispatchService) filterAvailableCouriers(order *orderpkg.Order, couriers []*courier.Courier) ([]*courier.Courier, error) {
+	now := s.now()
 	var result []*courier.Courier
 	for _, c := range couriers {
 		canTake, _ := c.CanTakeOrder(order)
-		if canTake {
+		if !canTake {
+			continue
+		}
+		inTime, err := s.deliversInTime(order, c, now)
+		if err != nil {
+			return nil, err
+		}
+		if inTime {
 			result = append(result, c)
 		}
 	}
 	return result, nil
 }
 
-func (s *dispatchService) findFastestCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
-	var (
-		best    *courier.Courier
-		minTime = math.MaxFloat64
-	)
-	location := order.Location()
-	for _, c := range couriers {
-		time, err := c.StepsTo(location)
-		if err != nil {
-			return nil, err
-		}
-		if time < minTime {
-			minTime = time
-			best = c
-		}
+// deliversInTime - курьер успеет привезти заказ в окно доставки. Для опоздавшего заказа окно
+// уже не важно: его везет любой курьер, и как можно скорее. Курьер, который не может доехать
+// до заказа, не успевает никогда
+func (s *dispatchService) deliversInTime(order *orderpkg.Order, c *courier.Courier, now time.Time) (bool, error) {
+	window := order.DeliveryWindow()
+	if window.IsEmpty() || window.HasPassed(now) {
+		return true, nil
 	}
-	return best, nil
+	arrival, err := s.navigator.ArrivalTime(c, order, now)
+	if errors.Is(err, kernel.ErrRouteNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return window.CanBeMet(arrival), nil
 }
@@ -4,16 +4,20 @@ import (
 	"delivery/internal/core/domain/model/courier"
 	"delivery/internal/core/domain/model/kernel"
 	"delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func Test_DispatchService(t *testing.T) {
 	// Arrange
 	makeCourier := func(name string, x, y int) *courier.Courier {
 		loc, _ := kernel.NewLocation(x, y)
-		c, _ := courier.NewCourier(name, 1, loc)
+		c, _ := courier.NewCourier(name, courier.Pedestrian, loc)
+		_ = c.StartShift()
 		err := c.AddStoragePlace("Сумка", 10)
 		if err != nil {
 			return nil
@@ -37,7 +41,518 @@ func Test_DispatchService(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, courier2, winner)
-	assert.Equal(t, orderAggregate.Id(), *courier2.StoragePlaces()[0].OrderID())
+	assert.Contains(t, courier2.StoragePlaces()[0].OrderIDs(), orderAggregate.Id())
 	assert.Equal(t, order.StatusAssigned, orderAggregate.Status())
 	assert.Equal(t, courier2.ID(), *orderAggregate.CourierId())
 }
+
+func Test_DispatchService_PartiallyLoadedCourier(t *testing.T) {
+	// Arrange
+	makeCourier := func(name string, x, y int) *courier.Courier {
+		loc, _ := kernel.NewLocation(x, y)
+		c, _ := courier.NewCourier(name, courier.Pedestrian, loc)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+		_ = c.AddStoragePlace("Багажник", 10)
+		return c
+	}
+	// Data
+	orderLocation, _ := kernel.NewLocation(2, 2)
+	loadedCourier := makeCourier("Pedestrian 1", 2, 2) // <- should win
+	farCourier := makeCourier("Pedestrian 2", 9, 9)
+	couriers := []*courier.Courier{farCourier, loadedCourier}
+
+	dispatchService := NewDispatchService()
+
+	firstOrder, _ := order.NewOrder(uuid.New(), orderLocation, 5)
+	_, err := dispatchService.Dispatch(firstOrder, couriers)
+	assert.NoError(t, err)
+
+	secondOrder, _ := order.NewOrder(uuid.New(), orderLocation, 5)
+
+	// Act
+	winner, err := dispatchService.Dispatch(secondOrder, couriers)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, loadedCourier, winner)
+	assert.ElementsMatch(t, []uuid.UUID{firstOrder.Id(), secondOrder.Id()}, loadedCourier.OrderIDs())
+}
+
+func Test_DispatchService_Strategies(t *testing.T) {
+	// Arrange
+	makeCourier := func(name string, speed, x, y, load int) *courier.Courier {
+		loc, _ := kernel.NewLocation(x, y)
+		transport, _ := courier.NewTransport(name, speed, 15, 20)
+		c, _ := courier.NewCourier(name, transport, loc)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+		if load > 0 {
+			loadOrder, _ := order.NewOrder(uuid.New(), loc, load)
+			_ = c.TakeOrder(loadOrder)
+		}
+		return c
+	}
+	// Data
+	// nearby: расстояние 1, время 1, загружен
+	// fast:   расстояние 2, время 0.5, загружен
+	// empty:  расстояние 8, время 8, свободен
+	makeCouriers := func() (nearby, fast, empty *courier.Courier) {
+		return makeCourier("Pedestrian", 1, 5, 4, 5),
+			makeCourier("Car", 4, 5, 7, 5),
+			makeCourier("Pedestrian 2", 1, 9, 9, 0)
+	}
+
+	tests := map[string]struct {
+		strategy func(Navigator) (DispatchStrategy, error)
+		want     func(nearby, fast, empty *courier.Courier) *courier.Courier
+	}{
+		"nearest": {
+			strategy: NewNearestCourierStrategy,
+			want:     func(nearby, _, _ *courier.Courier) *courier.Courier { return nearby },
+		},
+		"fastest": {
+			strategy: NewFastestCourierStrategy,
+			want:     func(_, fast, _ *courier.Courier) *courier.Courier { return fast },
+		},
+		"least loaded": {
+			strategy: NewLeastLoadedCourierStrategy,
+			want:     func(_, _, empty *courier.Courier) *courier.Courier { return empty },
+		},
+		"round robin picks fastest when nobody has orders yet": {
+			strategy: NewRoundRobinStrategy,
+			want:     func(_, fast, _ *courier.Courier) *courier.Courier { return fast },
+		},
+		"weighted by time and distance": {
+			strategy: func(navigator Navigator) (DispatchStrategy, error) {
+				return NewWeightedScoreStrategy(navigator, Weights{Time: 1, Distance: 1})
+			},
+			want: func(nearby, _, _ *courier.Courier) *courier.Courier { return nearby },
+		},
+		"weighted by load": {
+			strategy: func(navigator Navigator) (DispatchStrategy, error) {
+				return NewWeightedScoreStrategy(navigator, Weights{Load: 1})
+			},
+			want: func(_, _, empty *courier.Courier) *courier.Courier { return empty },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nearby, fast, empty := makeCouriers()
+			orderLocation, _ := kernel.NewLocation(5, 5)
+			orderAggregate, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+
+			navigator := NewGridNavigator()
+			strategy, err := test.strategy(navigator)
+			assert.NoError(t, err)
+			dispatchService, err := NewDispatchServiceWithStrategy(strategy, navigator)
+			assert.NoError(t, err)
+
+			// Act
+			winner, err := dispatchService.Dispatch(orderAggregate, []*courier.Courier{nearby, fast, empty})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.want(nearby, fast, empty), winner)
+			assert.Equal(t, winner.ID(), *orderAggregate.CourierId())
+		})
+	}
+}
+
+func Test_DispatchService_RoundRobin_Alternates(t *testing.T) {
+	// Arrange
+	makeCourier := func(name string, x, y int) *courier.Courier {
+		loc, _ := kernel.NewLocation(x, y)
+		c, _ := courier.NewCourier(name, courier.Pedestrian, loc)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+		return c
+	}
+	near := makeCourier("Pedestrian 1", 2, 2)
+	far := makeCourier("Pedestrian 2", 9, 9)
+	couriers := []*courier.Courier{near, far}
+	orderLocation, _ := kernel.NewLocation(2, 2)
+
+	navigator := NewGridNavigator()
+	strategy, err := NewRoundRobinStrategy(navigator)
+	assert.NoError(t, err)
+	dispatchService, err := NewDispatchServiceWithStrategy(strategy, navigator)
+	assert.NoError(t, err)
+
+	// Act
+	var winners []*courier.Courier
+	for i := 0; i < 3; i++ {
+		orderAggregate, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+		winner, err := dispatchService.Dispatch(orderAggregate, couriers)
+		assert.NoError(t, err)
+		winners = append(winners, winner)
+	}
+
+	// Assert
+	assert.Equal(t, []*courier.Courier{near, far, near}, winners)
+}
+
+func Test_NewDispatchStrategy(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		wantErr bool
+	}{
+		"default":      {name: ""},
+		"nearest":      {name: StrategyNearest},
+		"fastest":      {name: StrategyFastest},
+		"least loaded": {name: StrategyLeastLoaded},
+		"round robin":  {name: StrategyRoundRobin},
+		"weighted":     {name: StrategyWeighted},
+		"unknown":      {name: "teleport", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			strategy, err := NewDispatchStrategy(test.name, NewGridNavigator())
+
+			// Assert
+			if test.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+				assert.Nil(t, strategy)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, strategy)
+		})
+	}
+}
+
+func Test_DispatchService_DispatchAll(t *testing.T) {
+	// Arrange
+	// Каждый курьер может взять только один большой заказ
+	makeCourier := func(name string, x, y int) *courier.Courier {
+		loc, _ := kernel.NewLocation(x, y)
+		c, _ := courier.NewCourier(name, courier.Car, loc)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Багажник", 50)
+		return c
+	}
+	makeOrder := func(x, y int) *order.Order {
+		loc, _ := kernel.NewLocation(x, y)
+		o, _ := order.NewOrder(uuid.New(), loc, 41)
+		return o
+	}
+	// Data
+	courier1 := makeCourier("Car 1", 2, 1)
+	courier2 := makeCourier("Car 2", 9, 1)
+	order1 := makeOrder(3, 1)  // courier1: 1, courier2: 6
+	order2 := makeOrder(10, 1) // courier1: 8, courier2: 1
+	order3 := makeOrder(5, 5)  // курьеров не хватит
+	orders := []*order.Order{order1, order2, order3}
+
+	dispatchService := NewDispatchService()
+
+	// Act
+	assignments, err := dispatchService.DispatchAll(orders, []*courier.Courier{courier1, courier2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []Assignment{
+		{Order: order1, Courier: courier1},
+		{Order: order2, Courier: courier2},
+	}, assignments)
+	assert.Equal(t, courier1.ID(), *order1.CourierId())
+	assert.Equal(t, courier2.ID(), *order2.CourierId())
+	assert.Equal(t, order.StatusCreated, order3.Status())
+	assert.Nil(t, order3.CourierId())
+}
+
+func Test_DispatchService_DispatchAll_SharedCapacity(t *testing.T) {
+	// Arrange
+	loc, _ := kernel.NewLocation(1, 1)
+	c, _ := courier.NewCourier("Car", courier.Car, loc)
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Багажник", 100)
+
+	var orders []*order.Order
+	for i := 0; i < 3; i++ {
+		o, _ := order.NewOrder(uuid.New(), loc, 45)
+		orders = append(orders, o)
+	}
+
+	dispatchService := NewDispatchService()
+
+	// Act
+	assignments, err := dispatchService.DispatchAll(orders, []*courier.Courier{c})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, assignments, 2)
+	assert.Len(t, c.OrderIDs(), 2)
+	assert.Equal(t, order.StatusCreated, orders[2].Status())
+}
+
+func Test_DispatchService_DispatchAll_MinimisesTotalTime(t *testing.T) {
+	// Arrange
+	// Каждый курьер может взять только один большой заказ.
+	// Первому заказу ближе всех первый курьер, но тогда второму заказу достанется дальний второй
+	makeCouriers := func() (first, second *courier.Courier) {
+		firstLocation, _ := kernel.NewLocation(5, 1)
+		first, _ = courier.NewCourier("Car 1", courier.Car, firstLocation)
+		secondLocation, _ := kernel.NewLocation(1, 1)
+		second, _ = courier.NewCourier("Car 2", courier.Car, secondLocation)
+		for _, c := range []*courier.Courier{first, second} {
+			_ = c.StartShift()
+			_ = c.AddStoragePlace("Багажник", 50)
+		}
+		return first, second
+	}
+	makeOrders := func() (near, far *order.Order) {
+		nearLocation, _ := kernel.NewLocation(4, 1) // first: 1, second: 3
+		near, _ = order.NewOrder(uuid.New(), nearLocation, 41)
+		farLocation, _ := kernel.NewLocation(7, 1) // first: 2, second: 6
+		far, _ = order.NewOrder(uuid.New(), farLocation, 41)
+		return near, far
+	}
+	navigator := NewGridNavigator()
+	totalSteps := func(assignments []Assignment) float64 {
+		total := 0.0
+		for _, assignment := range assignments {
+			steps, err := navigator.StepsTo(assignment.Courier, assignment.Order)
+			assert.NoError(t, err)
+			total += steps
+		}
+		return total
+	}
+	dispatchService := NewDispatchService()
+
+	// Act: по одному заказу
+	first, second := makeCouriers()
+	near, far := makeOrders()
+	var greedy []Assignment
+	for _, o := range []*order.Order{near, far} {
+		winner, err := dispatchService.Dispatch(o, []*courier.Courier{first, second})
+		assert.NoError(t, err)
+		greedy = append(greedy, Assignment{Order: o, Courier: winner})
+	}
+
+	// Act: всей пачкой
+	first, second = makeCouriers()
+	near, far = makeOrders()
+	batch, err := dispatchService.DispatchAll([]*order.Order{near, far}, []*courier.Courier{first, second})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []Assignment{{Order: near, Courier: second}, {Order: far, Courier: first}}, batch)
+	assert.Less(t, totalSteps(batch), totalSteps(greedy))
+}
+
+func Test_SolveAssignment(t *testing.T) {
+	tests := map[string]struct {
+		cost     [][]float64
+		expected []int
+	}{
+		"square": {
+			cost:     [][]float64{{4, 1, 3}, {2, 0, 5}, {3, 2, 2}},
+			expected: []int{1, 0, 2},
+		},
+		"more columns than rows": {
+			cost:     [][]float64{{1, 3, 6}, {2, 6, 9}},
+			expected: []int{1, 0},
+		},
+		"more rows than columns": {
+			cost:     [][]float64{{5}, {1}, {3}},
+			expected: []int{-1, 0, -1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, solveAssignment(test.cost))
+		})
+	}
+}
+
+func Test_DispatchService_DeliveryWindow(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	makeOrder := func(x, y int, to time.Time) *order.Order {
+		loc, _ := kernel.NewLocation(x, y)
+		o, _ := order.NewOrder(uuid.New(), loc, 5)
+		window, _ := order.NewDeliveryWindow(now.Add(-time.Hour), to)
+		_ = o.SetDeliveryWindow(window)
+		return o
+	}
+	loc, _ := kernel.NewLocation(1, 1)
+	c, _ := courier.NewCourier("Pedestrian", courier.Pedestrian, loc)
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Сумка", 10)
+
+	navigator := NewGridNavigator()
+	strategy, _ := NewFastestCourierStrategy(navigator)
+	dispatchService := &dispatchService{strategy: strategy, navigator: navigator, now: func() time.Time { return now }}
+
+	// Курьеру 8 шагов, окно закроется через 30 секунд - не успеет
+	missed := makeOrder(5, 5, now.Add(30*time.Second))
+	_, err := dispatchService.Dispatch(missed, []*courier.Courier{c})
+	assert.ErrorIs(t, err, ErrSuitableCourierWasNotFound)
+	assert.Equal(t, order.StatusCreated, missed.Status())
+
+	// Окно закроется через 2 минуты - успеет
+	inTime := makeOrder(5, 5, now.Add(2*time.Minute))
+	winner, err := dispatchService.Dispatch(inTime, []*courier.Courier{c})
+	assert.NoError(t, err)
+	assert.Equal(t, c, winner)
+
+	// Окно уже закрылось - опоздавший заказ везет любой курьер
+	late := makeOrder(5, 5, now.Add(-time.Minute))
+	winner, err = dispatchService.Dispatch(late, []*courier.Courier{c})
+	assert.NoError(t, err)
+	assert.Equal(t, c, winner)
+}
+
+func Test_DispatchService_DispatchAll_ClosingWindowFirst(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	loc, _ := kernel.NewLocation(1, 1)
+	c, _ := courier.NewCourier("Car", courier.Car, loc)
+	_ = c.StartShift()
+	_ = c.AddStoragePlace("Багажник", 50)
+
+	nearLocation, _ := kernel.NewLocation(2, 1)
+	near, _ := order.NewOrder(uuid.New(), nearLocation, 41)
+
+	farLocation, _ := kernel.NewLocation(5, 1)
+	urgent, _ := order.NewOrder(uuid.New(), farLocation, 41)
+	window, _ := order.NewDeliveryWindow(now, now.Add(time.Hour))
+	_ = urgent.SetDeliveryWindow(window)
+
+	navigator := NewGridNavigator()
+	strategy, _ := NewFastestCourierStrategy(navigator)
+	dispatchService := &dispatchService{strategy: strategy, navigator: navigator, now: func() time.Time { return now }}
+
+	// Act
+	assignments, err := dispatchService.DispatchAll([]*order.Order{near, urgent}, []*courier.Courier{c})
+
+	// Assert: места хватает на один заказ, и достается он заказу с окном, хотя он дальше
+	assert.NoError(t, err)
+	assert.Equal(t, []Assignment{{Order: urgent, Courier: c}}, assignments)
+	assert.Equal(t, order.StatusCreated, near.Status())
+}
+
+func Test_DispatchService_UsesRouteDistance(t *testing.T) {
+	// Arrange: стена по x = 2 с проходом сверху, правый верхний угол отрезан от карты
+	grid, _ := kernel.NewGrid("test", 1, 1, 5, 5)
+	grid, _ = grid.WithBlockedCells(
+		kernel.Cell{X: 2, Y: 1}, kernel.Cell{X: 2, Y: 2}, kernel.Cell{X: 2, Y: 3}, kernel.Cell{X: 2, Y: 4},
+		kernel.Cell{X: 4, Y: 5}, kernel.Cell{X: 5, Y: 4})
+	makeCourier := func(name string, x, y int) *courier.Courier {
+		loc, _ := grid.NewLocation(x, y)
+		c, _ := courier.NewCourier(name, courier.Pedestrian, loc)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+		return c
+	}
+	behindWall := makeCourier("Behind the wall", 1, 1) // по прямой 2 клетки, в обход 10
+	openRoad := makeCourier("Open road", 3, 4)         // <- should win
+	trapped := makeCourier("Trapped", 5, 5)            // не доедет вовсе
+
+	orderLocation, _ := grid.NewLocation(3, 1)
+	orderAggregate, _ := order.NewOrder(uuid.New(), orderLocation, 5)
+
+	for _, strategyName := range []string{StrategyNearest, StrategyFastest, StrategyWeighted} {
+		strategy, _ := NewDispatchStrategy(strategyName, NewGridNavigator())
+
+		// Act
+		winner, err := strategy.SelectCourier(orderAggregate, []*courier.Courier{trapped, behindWall, openRoad})
+
+		// Assert
+		assert.NoError(t, err, strategyName)
+		assert.Equal(t, openRoad, winner, strategyName)
+	}
+}
+
+func Test_DispatchService_RespectsTransport(t *testing.T) {
+	// Arrange
+	loc, _ := kernel.NewLocation(2, 2)
+	pedestrian, _ := courier.NewCourier("Pedestrian", courier.Pedestrian, loc)
+	_ = pedestrian.StartShift()
+	_ = pedestrian.AddStoragePlace("Сумка", 10)
+
+	farLocation, _ := kernel.NewLocation(9, 9)
+	car, _ := courier.NewCourier("Car", courier.Car, farLocation)
+	_ = car.StartShift()
+	_ = car.AddStoragePlace("Багажник", 100)
+
+	bigOrder, _ := order.NewOrder(uuid.New(), loc, 25)
+
+	// Act
+	winner, err := NewDispatchService().Dispatch(bigOrder, []*courier.Courier{pedestrian, car})
+
+	// Assert: пеший курьер рядом, но такой заказ везет только машина
+	assert.NoError(t, err)
+	assert.Equal(t, car, winner)
+}
+
+func Test_DispatchService_SkipsUnreachableCouriers(t *testing.T) {
+	// Arrange: река по x = 3 без мостов делит карту на два берега
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	grid, _ := kernel.NewGrid("test", 1, 1, 5, 5)
+	grid, _ = grid.WithBlockedCells(kernel.Cell{X: 3, Y: 1}, kernel.Cell{X: 3, Y: 2}, kernel.Cell{X: 3, Y: 3},
+		kernel.Cell{X: 3, Y: 4}, kernel.Cell{X: 3, Y: 5})
+	makeOrder := func(x, y int, window bool) *order.Order {
+		loc, _ := grid.NewLocation(x, y)
+		o, _ := order.NewOrder(uuid.New(), loc, 1)
+		if window {
+			deliveryWindow, _ := order.NewDeliveryWindow(now.Add(-time.Hour), now.Add(time.Hour))
+			_ = o.SetDeliveryWindow(deliveryWindow)
+		}
+		return o
+	}
+	// Левый курьер свободен и стоит в начале списка - любая стратегия выбрала бы его, будь у него путь
+	makeCouriers := func() (left, right *courier.Courier) {
+		leftLocation, _ := grid.NewLocation(2, 1)
+		left, _ = courier.NewCourier("Left bank", courier.Car, leftLocation)
+		rightLocation, _ := grid.NewLocation(5, 5)
+		right, _ = courier.NewCourier("Right bank", courier.Pedestrian, rightLocation)
+		for _, c := range []*courier.Courier{left, right} {
+			_ = c.StartShift()
+			_ = c.AddStoragePlace("Сумка", 10)
+		}
+		_ = right.TakeOrder(makeOrder(5, 4, false))
+		return left, right
+	}
+
+	strategies := []string{StrategyNearest, StrategyFastest, StrategyLeastLoaded, StrategyRoundRobin, StrategyWeighted}
+	for _, strategyName := range strategies {
+		for _, window := range []bool{false, true} {
+			name := fmt.Sprintf("%s, window %v", strategyName, window)
+			newService := func() DispatchService {
+				navigator := NewGridNavigator()
+				strategy, _ := NewDispatchStrategy(strategyName, navigator)
+				return &dispatchService{strategy: strategy, navigator: navigator, now: func() time.Time { return now }}
+			}
+
+			// Act & Assert: Dispatch
+			left, right := makeCouriers()
+			rightOrder := makeOrder(4, 1, window)
+			winner, err := newService().Dispatch(rightOrder, []*courier.Courier{left, right})
+			assert.NoError(t, err, name)
+			assert.Equal(t, right, winner, name)
+
+			_, err = newService().Dispatch(makeOrder(4, 2, window), []*courier.Courier{left})
+			assert.ErrorIs(t, err, ErrSuitableCourierWasNotFound, name)
+
+			// Act & Assert: DispatchAll
+			left, right = makeCouriers()
+			leftOrder, rightOrder := makeOrder(1, 5, window), makeOrder(4, 1, window)
+			assignments, err := newService().DispatchAll([]*order.Order{rightOrder, leftOrder}, []*courier.Courier{left, right})
+			assert.NoError(t, err, name)
+			assert.ElementsMatch(t, []Assignment{{Order: rightOrder, Courier: right}, {Order: leftOrder, Courier: left}},
+				assignments, name)
+
+			left, _ = makeCouriers()
+			unreachable := makeOrder(4, 3, window)
+			assignments, err = newService().DispatchAll([]*order.Order{unreachable}, []*courier.Courier{left})
+			assert.NoError(t, err, name)
+			assert.Empty(t, assignments, name)
+			assert.Equal(t, order.StatusCreated, unreachable.Status(), name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"fmt"
+)
+
+// DispatchStrategy выбирает курьера для заказа среди тех, кто может его взять
+type DispatchStrategy interface {
+	SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error)
+}
+
+const (
+	StrategyNearest     = "nearest"
+	StrategyFastest     = "fastest"
+	StrategyLeastLoaded = "least-loaded"
+	StrategyRoundRobin  = "round-robin"
+	StrategyWeighted    = "weighted"
+)
+
+// NewDispatchStrategy создает стратегию по имени. Расстояние и время в пути стратегия считает
+// через navigator - так же, как потом поедет курьер
+func NewDispatchStrategy(name string, navigator Navigator) (DispatchStrategy, error) {
+	switch name {
+	case StrategyFastest, "":
+		return NewFastestCourierStrategy(navigator)
+	case StrategyNearest:
+		return NewNearestCourierStrategy(navigator)
+	case StrategyLeastLoaded:
+		return NewLeastLoadedCourierStrategy(navigator)
+	case StrategyRoundRobin:
+		return NewRoundRobinStrategy(navigator)
+	case StrategyWeighted:
+		return NewWeightedScoreStrategy(navigator, DefaultWeights())
+	default:
+		return nil, errs.NewValueIsInvalidErrorWithCause("strategy",
+			fmt.Errorf("unknown dispatch strategy %q", name))
+	}
+}
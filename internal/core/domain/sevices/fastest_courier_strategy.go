@@ -0,0 +1,48 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"math"
+)
+
+var _ DispatchStrategy = &fastestCourierStrategy{}
+
+// fastestCourierStrategy выбирает курьера, который быстрее всех доберется до заказа
+type fastestCourierStrategy struct {
+	navigator Navigator
+}
+
+func NewFastestCourierStrategy(navigator Navigator) (DispatchStrategy, error) {
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &fastestCourierStrategy{navigator: navigator}, nil
+}
+
+func (s *fastestCourierStrategy) SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	var (
+		best    *courier.Courier
+		minTime = math.MaxFloat64
+	)
+	for _, c := range couriers {
+		time, err := s.navigator.StepsTo(c, order)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if time < minTime {
+			minTime = time
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrSuitableCourierWasNotFound
+	}
+	return best, nil
+}
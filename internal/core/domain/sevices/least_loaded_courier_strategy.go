@@ -0,0 +1,52 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"math"
+)
+
+var _ DispatchStrategy = &leastLoadedCourierStrategy{}
+
+// leastLoadedCourierStrategy выбирает наименее загруженного курьера,
+// при равной загрузке - того, кто быстрее доберется до заказа
+type leastLoadedCourierStrategy struct {
+	navigator Navigator
+}
+
+func NewLeastLoadedCourierStrategy(navigator Navigator) (DispatchStrategy, error) {
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &leastLoadedCourierStrategy{navigator: navigator}, nil
+}
+
+func (s *leastLoadedCourierStrategy) SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	var (
+		best    *courier.Courier
+		minLoad = math.MaxFloat64
+		minTime = math.MaxFloat64
+	)
+	for _, c := range couriers {
+		time, err := s.navigator.StepsTo(c, order)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		load := c.Load()
+		if load < minLoad || (load == minLoad && time < minTime) {
+			minLoad = load
+			minTime = time
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrSuitableCourierWasNotFound
+	}
+	return best, nil
+}
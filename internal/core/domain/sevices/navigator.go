@@ -0,0 +1,192 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	MovementGrid = "grid"
+	MovementGeo  = "geo"
+)
+
+// Navigator решает, как курьер едет к заказам: по клеткам сетки или по географическим координатам
+type Navigator interface {
+	// NearestOrder выбирает заказ, до которого курьеру быстрее всего добраться
+	NearestOrder(c *courier.Courier, orders []*orderpkg.Order) (*orderpkg.Order, error)
+
+	// StepTowards сдвигает курьера на один шаг к заказу
+	StepTowards(c *courier.Courier, order *orderpkg.Order) error
+
+	// HasArrived - добрался ли курьер до заказа
+	HasArrived(c *courier.Courier, order *orderpkg.Order) bool
+
+	// StepsTo - сколько шагов курьеру ехать до заказа
+	StepsTo(c *courier.Courier, order *orderpkg.Order) (float64, error)
+
+	// DistanceTo - расстояние от курьера до заказа в клетках. Любой навигатор возвращает его в клетках,
+	// чтобы стратегии сравнивали курьеров в одних единицах и складывали расстояние с шагами
+	DistanceTo(c *courier.Courier, order *orderpkg.Order) (float64, error)
+
+	// ArrivalTime - момент, когда курьер, выехав в now, доберется до заказа
+	ArrivalTime(c *courier.Courier, order *orderpkg.Order, now time.Time) (time.Time, error)
+}
+
+func NewNavigator(model string) (Navigator, error) {
+	switch model {
+	case MovementGrid, "":
+		return &gridNavigator{}, nil
+	case MovementGeo:
+		return &geoNavigator{}, nil
+	default:
+		return nil, errs.NewValueIsInvalidErrorWithCause("movementModel",
+			fmt.Errorf("unknown movement model %q", model))
+	}
+}
+
+// metresPerCell - сколько метров в клетке сетки: клетка - путь, который пешеход проходит за шаг.
+// По нему расстояние по координатам сравнивается с расстоянием по сетке
+var metresPerCell = courier.Pedestrian.MetresPerStep() / float64(courier.Pedestrian.Speed())
+
+// NewGridNavigator - навигатор по клеткам сетки, модель перемещения по умолчанию
+func NewGridNavigator() Navigator {
+	return &gridNavigator{}
+}
+
+var _ Navigator = &gridNavigator{}
+
+// gridNavigator двигает курьера по клеткам сетки
+type gridNavigator struct{}
+
+func (n *gridNavigator) NearestOrder(c *courier.Courier, orders []*orderpkg.Order) (*orderpkg.Order, error) {
+	return c.NearestOrder(orders)
+}
+
+func (n *gridNavigator) StepTowards(c *courier.Courier, order *orderpkg.Order) error {
+	return c.StepTowards(order.Location())
+}
+
+func (n *gridNavigator) HasArrived(c *courier.Courier, order *orderpkg.Order) bool {
+	return c.Location().Equals(order.Location())
+}
+
+func (n *gridNavigator) StepsTo(c *courier.Courier, order *orderpkg.Order) (float64, error) {
+	return c.StepsTo(order.Location())
+}
+
+func (n *gridNavigator) DistanceTo(c *courier.Courier, order *orderpkg.Order) (float64, error) {
+	distance, err := c.Location().DistanceTo(order.Location())
+	if err != nil {
+		return 0, err
+	}
+	return float64(distance), nil
+}
+
+func (n *gridNavigator) ArrivalTime(c *courier.Courier, order *orderpkg.Order, now time.Time) (time.Time, error) {
+	return c.ArrivalTime(order.Location(), now)
+}
+
+var _ Navigator = &geoNavigator{}
+
+// geoNavigator двигает курьера по координатам. Если у курьера или заказа координат нет,
+// эта пара едет по сетке
+type geoNavigator struct {
+	gridNavigator
+}
+
+func (n *geoNavigator) NearestOrder(c *courier.Courier, orders []*orderpkg.Order) (*orderpkg.Order, error) {
+	if len(orders) == 0 {
+		return nil, errs.NewValueIsRequiredError("orders")
+	}
+
+	var (
+		nearest  *orderpkg.Order
+		minSteps = math.MaxFloat64
+	)
+	for _, o := range orders {
+		if o == nil {
+			return nil, errs.NewValueIsRequiredError("order")
+		}
+		steps, err := n.StepsTo(c, o)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if steps < minSteps {
+			minSteps = steps
+			nearest = o
+		}
+	}
+	if nearest == nil {
+		return nil, kernel.ErrRouteNotFound
+	}
+	return nearest, nil
+}
+
+func (n *geoNavigator) StepTowards(c *courier.Courier, order *orderpkg.Order) error {
+	if !canUseCoordinates(c, order) {
+		return n.gridNavigator.StepTowards(c, order)
+	}
+
+	err := c.StepTowardsCoordinate(order.Coordinate())
+	if err != nil {
+		return err
+	}
+
+	// Доехали - клетка сетки тоже совпадает с клеткой заказа
+	if c.Coordinate().Equals(order.Coordinate()) {
+		return c.MoveTo(order.Location())
+	}
+	return nil
+}
+
+func (n *geoNavigator) HasArrived(c *courier.Courier, order *orderpkg.Order) bool {
+	if !canUseCoordinates(c, order) {
+		return n.gridNavigator.HasArrived(c, order)
+	}
+	return c.Coordinate().Equals(order.Coordinate())
+}
+
+func (n *geoNavigator) StepsTo(c *courier.Courier, order *orderpkg.Order) (float64, error) {
+	if !canUseCoordinates(c, order) {
+		return n.gridNavigator.StepsTo(c, order)
+	}
+	return c.GeoStepsTo(order.Coordinate())
+}
+
+// DistanceTo переводит метры между координатами в клетки, иначе пары с координатами и без них
+// нельзя было бы сравнить
+func (n *geoNavigator) DistanceTo(c *courier.Courier, order *orderpkg.Order) (float64, error) {
+	if !canUseCoordinates(c, order) {
+		return n.gridNavigator.DistanceTo(c, order)
+	}
+	metres, err := c.Coordinate().DistanceTo(order.Coordinate())
+	if err != nil {
+		return 0, err
+	}
+	return metres / metresPerCell, nil
+}
+
+// ArrivalTime считает шаги так же, как StepTowards: последний неполный шаг тоже занимает шаг
+func (n *geoNavigator) ArrivalTime(c *courier.Courier, order *orderpkg.Order, now time.Time) (time.Time, error) {
+	if !canUseCoordinates(c, order) {
+		return n.gridNavigator.ArrivalTime(c, order, now)
+	}
+	steps, err := n.StepsTo(c, order)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(time.Duration(math.Ceil(steps)) * courier.StepDuration), nil
+}
+
+func canUseCoordinates(c *courier.Courier, order *orderpkg.Order) bool {
+	return !c.Coordinate().IsEmpty() && !order.Coordinate().IsEmpty()
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_NewNavigator(t *testing.T) {
+	for _, model := range []string{"", MovementGrid, MovementGeo} {
+		navigator, err := NewNavigator(model)
+		assert.NoError(t, err)
+		assert.NotNil(t, navigator)
+	}
+
+	_, err := NewNavigator("teleport")
+	assert.Error(t, err)
+}
+
+func Test_GeoNavigator_MovesByCoordinates(t *testing.T) {
+	// Arrange
+	courierLocation, _ := kernel.NewLocation(1, 1)
+	c, _ := courier.NewCourier("Courier", courier.Car, courierLocation)
+	start, _ := kernel.NewGeoCoordinate(55.7558, 37.6173)
+	_ = c.SetCoordinate(start)
+
+	orderLocation, _ := kernel.NewLocation(5, 5)
+	far, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+	farCoordinate, _ := kernel.NewGeoCoordinate(55.7758, 37.6173)
+	_ = far.SetCoordinate(farCoordinate)
+
+	// По сетке этот заказ ближе, но по координатам дальше
+	nearOrderLocation, _ := kernel.NewLocation(10, 10)
+	near, _ := order.NewOrder(uuid.New(), nearOrderLocation, 1)
+	nearCoordinate, _ := kernel.NewGeoCoordinate(55.7608, 37.6173)
+	_ = near.SetCoordinate(nearCoordinate)
+
+	navigator, _ := NewNavigator(MovementGeo)
+
+	// Act
+	nextOrder, err := navigator.NearestOrder(c, []*order.Order{far, near})
+	assert.NoError(t, err)
+	assert.Equal(t, near, nextOrder)
+
+	// 30 км/ч - около 83 м за шаг, до заказа около 556 м
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	eta, err := navigator.ArrivalTime(c, near, now)
+	assert.NoError(t, err)
+	ticks := 0
+	for !navigator.HasArrived(c, near) {
+		assert.NoError(t, navigator.StepTowards(c, near))
+		ticks++
+	}
+
+	// Assert
+	assert.Equal(t, 7, ticks)
+	assert.Equal(t, now.Add(time.Duration(ticks)*courier.StepDuration), eta)
+	assert.True(t, c.Location().Equals(nearOrderLocation))
+}
+
+func Test_DispatchService_GeoMovementUsesCoordinates(t *testing.T) {
+	// Arrange: по сетке ближе первый курьер, по координатам - второй
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	makeCourier := func(name string, x, y int, latitude float64) *courier.Courier {
+		location, _ := kernel.NewLocation(x, y)
+		c, _ := courier.NewCourier(name, courier.Bicycle, location)
+		coordinate, _ := kernel.NewGeoCoordinate(latitude, 37.6173)
+		_ = c.SetCoordinate(coordinate)
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+		return c
+	}
+	gridNear := makeCourier("Near on grid", 5, 4, 55.7858)
+	geoNear := makeCourier("Near on map", 1, 1, 55.7568) // <- should win
+
+	orderLocation, _ := kernel.NewLocation(5, 5)
+	makeOrder := func() *order.Order {
+		o, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+		coordinate, _ := kernel.NewGeoCoordinate(55.7558, 37.6173)
+		_ = o.SetCoordinate(coordinate)
+		return o
+	}
+
+	navigator, _ := NewNavigator(MovementGeo)
+	for _, strategyName := range []string{StrategyNearest, StrategyFastest, StrategyWeighted} {
+		strategy, _ := NewDispatchStrategy(strategyName, navigator)
+		dispatchService := &dispatchService{strategy: strategy, navigator: navigator, now: func() time.Time { return now }}
+
+		// Act
+		winner, err := dispatchService.Dispatch(makeOrder(), []*courier.Courier{gridNear, geoNear})
+
+		// Assert
+		assert.NoError(t, err, strategyName)
+		assert.Equal(t, geoNear, winner, strategyName)
+	}
+
+	// По сетке курьеру 4 шага, по координатам около 1112 м - 27 шагов на велосипеде:
+	// окно на 2 минуты он не успевает
+	strategy, _ := NewDispatchStrategy(StrategyFastest, navigator)
+	dispatchService := &dispatchService{strategy: strategy, navigator: navigator, now: func() time.Time { return now }}
+	far := makeCourier("Far on map", 1, 1, 55.7658)
+	o := makeOrder()
+	window, _ := order.NewDeliveryWindow(now.Add(-time.Hour), now.Add(2*time.Minute))
+	_ = o.SetDeliveryWindow(window)
+	_, err := dispatchService.Dispatch(o, []*courier.Courier{far})
+	assert.ErrorIs(t, err, ErrSuitableCourierWasNotFound)
+}
+
+func Test_GeoNavigator_FallsBackToGrid(t *testing.T) {
+	// Arrange
+	courierLocation, _ := kernel.NewLocation(1, 1)
+	c, _ := courier.NewCourier("Courier", courier.Pedestrian, courierLocation)
+	orderLocation, _ := kernel.NewLocation(2, 1)
+	o, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+	coordinate, _ := kernel.NewGeoCoordinate(55.7558, 37.6173)
+	_ = o.SetCoordinate(coordinate)
+
+	navigator, _ := NewNavigator(MovementGeo)
+
+	// Act
+	err := navigator.StepTowards(c, o)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, navigator.HasArrived(c, o))
+	assert.True(t, c.Coordinate().IsEmpty())
+}
+
+func Test_GeoNavigator_MeasuresMixedPairsInCells(t *testing.T) {
+	// Arrange
+	orderLocation, _ := kernel.NewLocation(5, 5)
+	orderAggregate, _ := order.NewOrder(uuid.New(), orderLocation, 1)
+	orderCoordinate, _ := kernel.NewGeoCoordinate(55.7558, 37.6173)
+	_ = orderAggregate.SetCoordinate(orderCoordinate)
+
+	// По сетке дальше, но по координатам до заказа около 28 м - две клетки
+	geoLocation, _ := kernel.NewLocation(1, 1)
+	withCoordinate, _ := courier.NewCourier("With coordinate", courier.Pedestrian, geoLocation)
+	courierCoordinate, _ := kernel.NewGeoCoordinate(55.75605, 37.6173)
+	_ = withCoordinate.SetCoordinate(courierCoordinate)
+
+	// Без координат едет по сетке: пять клеток
+	gridLocation, _ := kernel.NewLocation(5, 10)
+	withoutCoordinate, _ := courier.NewCourier("Without coordinate", courier.Pedestrian, gridLocation)
+
+	for _, c := range []*courier.Courier{withCoordinate, withoutCoordinate} {
+		_ = c.StartShift()
+		_ = c.AddStoragePlace("Сумка", 10)
+	}
+	navigator, _ := NewNavigator(MovementGeo)
+
+	// Act & Assert: обе пары измерены в клетках
+	distance, err := navigator.DistanceTo(withCoordinate, orderAggregate)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2, distance, 0.1)
+	distance, err = navigator.DistanceTo(withoutCoordinate, orderAggregate)
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, distance)
+
+	// Act & Assert: любая стратегия выбирает курьера, который на самом деле ближе
+	strategies := []string{StrategyNearest, StrategyFastest, StrategyLeastLoaded, StrategyWeighted}
+	for _, strategyName := range strategies {
+		strategy, err := NewDispatchStrategy(strategyName, navigator)
+		assert.NoError(t, err)
+
+		winner, err := strategy.SelectCourier(orderAggregate, []*courier.Courier{withoutCoordinate, withCoordinate})
+
+		assert.NoError(t, err, strategyName)
+		assert.Equal(t, withCoordinate, winner, strategyName)
+	}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"math"
+)
+
+var _ DispatchStrategy = &nearestCourierStrategy{}
+
+// nearestCourierStrategy выбирает курьера, который ближе всех к заказу, без учета скорости
+type nearestCourierStrategy struct {
+	navigator Navigator
+}
+
+func NewNearestCourierStrategy(navigator Navigator) (DispatchStrategy, error) {
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &nearestCourierStrategy{navigator: navigator}, nil
+}
+
+func (s *nearestCourierStrategy) SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	var (
+		best        *courier.Courier
+		minDistance = math.MaxFloat64
+	)
+	for _, c := range couriers {
+		distance, err := s.navigator.DistanceTo(c, order)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			// Курьер не может доехать до заказа - например, он на другом берегу реки без моста
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if distance < minDistance {
+			minDistance = distance
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrSuitableCourierWasNotFound
+	}
+	return best, nil
+}
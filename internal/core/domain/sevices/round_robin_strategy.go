@@ -0,0 +1,67 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"github.com/google/uuid"
+	"math"
+	"sync"
+)
+
+var _ DispatchStrategy = &roundRobinStrategy{}
+
+// roundRobinStrategy распределяет заказы по очереди: выбирает курьера, который дольше всех
+// не получал заказ, при равенстве - того, кто быстрее доберется до заказа
+type roundRobinStrategy struct {
+	navigator Navigator
+
+	mu           sync.Mutex
+	turn         uint64
+	lastAssigned map[uuid.UUID]uint64
+}
+
+func NewRoundRobinStrategy(navigator Navigator) (DispatchStrategy, error) {
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	return &roundRobinStrategy{
+		navigator:    navigator,
+		lastAssigned: make(map[uuid.UUID]uint64),
+	}, nil
+}
+
+func (s *roundRobinStrategy) SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		best     *courier.Courier
+		bestTurn uint64
+		minTime  = math.MaxFloat64
+	)
+	for _, c := range couriers {
+		time, err := s.navigator.StepsTo(c, order)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		turn := s.lastAssigned[c.ID()]
+		if best == nil || turn < bestTurn || (turn == bestTurn && time < minTime) {
+			bestTurn = turn
+			minTime = time
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrSuitableCourierWasNotFound
+	}
+
+	s.turn++
+	s.lastAssigned[best.ID()] = s.turn
+	return best, nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"delivery/internal/core/domain/model/courier"
+	"delivery/internal/core/domain/model/kernel"
+	orderpkg "delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/errs"
+	"errors"
+	"math"
+)
+
+var _ DispatchStrategy = &weightedScoreStrategy{}
+
+// Weights - веса критериев взвешенной стратегии, чем меньше итоговый балл, тем лучше курьер
+type Weights struct {
+	Time     float64
+	Distance float64
+	Load     float64
+}
+
+func DefaultWeights() Weights {
+	return Weights{
+		Time:     1,
+		Distance: 0.5,
+		Load:     10,
+	}
+}
+
+// weightedScoreStrategy выбирает курьера с минимальным взвешенным баллом
+// по времени в пути (в шагах), расстоянию до заказа (в клетках) и загрузке
+type weightedScoreStrategy struct {
+	navigator Navigator
+	weights   Weights
+}
+
+func NewWeightedScoreStrategy(navigator Navigator, weights Weights) (DispatchStrategy, error) {
+	if navigator == nil {
+		return nil, errs.NewValueIsRequiredError("navigator")
+	}
+	if weights.Time < 0 {
+		return nil, errs.NewValueIsOutOfRangeError("weights.Time", weights.Time, 0, math.MaxFloat64)
+	}
+	if weights.Distance < 0 {
+		return nil, errs.NewValueIsOutOfRangeError("weights.Distance", weights.Distance, 0, math.MaxFloat64)
+	}
+	if weights.Load < 0 {
+		return nil, errs.NewValueIsOutOfRangeError("weights.Load", weights.Load, 0, math.MaxFloat64)
+	}
+	return &weightedScoreStrategy{navigator: navigator, weights: weights}, nil
+}
+
+func (s *weightedScoreStrategy) SelectCourier(order *orderpkg.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	var (
+		best     *courier.Courier
+		minScore = math.MaxFloat64
+	)
+	for _, c := range couriers {
+		time, err := s.navigator.StepsTo(c, order)
+		if errors.Is(err, kernel.ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		distance, err := s.navigator.DistanceTo(c, order)
+		if err != nil {
+			return nil, err
+		}
+		score := s.weights.Time*time + s.weights.Distance*distance + s.weights.Load*c.Load()
+		if score < minScore {
+			minScore = score
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, ErrSuitableCourierWasNotFound
+	}
+	return best, nil
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// DeadLetterReplayer возвращает сообщения из DLQ на повторную обработку
+type DeadLetterReplayer interface {
+	// Replay переотправляет все сообщения, попавшие в DLQ с прошлого вызова, и возвращает их количество
+	Replay(ctx context.Context) (int, error)
+}
@@ -6,5 +6,6 @@ import (
 )
 
 type GeoClient interface {
-	GetGeolocation(ctx context.Context, street string) (kernel.Location, error)
+	// GetGeolocation возвращает клетку сетки для адреса и, если геокодер их знает, его координаты
+	GetGeolocation(ctx context.Context, street string) (kernel.Location, kernel.GeoCoordinate, error)
 }
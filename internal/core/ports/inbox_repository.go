@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"delivery/internal/pkg/inbox"
+)
+
+type InboxRepository interface {
+	// Add отмечает сообщение обработанным. Если оно или его корзина уже обработаны - inbox.ErrAlreadyProcessed
+	Add(ctx context.Context, message *inbox.Message) error
+	Exists(ctx context.Context, message *inbox.Message) (bool, error)
+}
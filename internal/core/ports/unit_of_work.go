@@ -11,4 +11,9 @@ type UnitOfWork interface {
 	Commit(ctx context.Context) error
 	CourierRepository() CourierRepository
 	OrderRepository() OrderRepository
+	InboxRepository() InboxRepository
 }
+
+// UnitOfWorkFactory создает новый UnitOfWork. UnitOfWork хранит открытую транзакцию и отслеживаемые агрегаты,
+// поэтому обработчик, который вызывают из нескольких горутин, берет свой UnitOfWork на каждый вызов
+type UnitOfWorkFactory func() (UnitOfWork, error)
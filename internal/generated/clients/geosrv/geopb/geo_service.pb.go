@@ -70,6 +70,7 @@ func (x *GetGeolocationRequest) GetStreet() string {
 type GetGeolocationReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Location      *Location              `protobuf:"bytes,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Coordinate    *Coordinate            `protobuf:"bytes,2,opt,name=Coordinate,proto3" json:"Coordinate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -111,6 +112,13 @@ func (x *GetGeolocationReply) GetLocation() *Location {
 	return nil
 }
 
+func (x *GetGeolocationReply) GetCoordinate() *Coordinate {
+	if x != nil {
+		return x.Coordinate
+	}
+	return nil
+}
+
 // Geolocation
 type Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -164,6 +172,59 @@ func (x *Location) GetY() int32 {
 	return 0
 }
 
+// Geographic coordinate
+type Coordinate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinate) Reset() {
+	*x = Coordinate{}
+	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinate) ProtoMessage() {}
+
+func (x *Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Coordinate) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinate) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
 type ErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
@@ -173,7 +234,7 @@ type ErrorResponse struct {
 
 func (x *ErrorResponse) Reset() {
 	*x = ErrorResponse{}
-	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	mi := &file_api_proto_geo_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -185,7 +246,7 @@ func (x *ErrorResponse) String() string {
 func (*ErrorResponse) ProtoMessage() {}
 
 func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_geo_service_proto_msgTypes[3]
+	mi := &file_api_proto_geo_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +259,7 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_geo_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ErrorResponse) GetText() string {
@@ -214,12 +275,19 @@ const file_api_proto_geo_service_proto_rawDesc = "" +
 	"\n" +
 	"\x1bapi/proto/geo_service.proto\x12\x03geo\"/\n" +
 	"\x15GetGeolocationRequest\x12\x16\n" +
-	"\x06Street\x18\x01 \x01(\tR\x06Street\"@\n" +
+	"\x06Street\x18\x01 \x01(\tR\x06Street\"q\n" +
 	"\x13GetGeolocationReply\x12)\n" +
-	"\bLocation\x18\x01 \x01(\v2\r.geo.LocationR\bLocation\"&\n" +
+	"\bLocation\x18\x01 \x01(\v2\r.geo.LocationR\bLocation\x12/\n" +
+	"\n" +
+	"Coordinate\x18\x02 \x01(\v2\x0f.geo.CoordinateR\n" +
+	"Coordinate\"&\n" +
 	"\bLocation\x12\f\n" +
 	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
-	"\x01y\x18\x02 \x01(\x05R\x01y\"#\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\"F\n" +
+	"\n" +
+	"Coordinate\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"#\n" +
 	"\rErrorResponse\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text2M\n" +
 	"\x03Geo\x12F\n" +
@@ -238,22 +306,24 @@ func file_api_proto_geo_service_proto_rawDescGZIP() []byte {
 	return file_api_proto_geo_service_proto_rawDescData
 }
 
-var file_api_proto_geo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_proto_geo_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_api_proto_geo_service_proto_goTypes = []any{
 	(*GetGeolocationRequest)(nil), // 0: geo.GetGeolocationRequest
 	(*GetGeolocationReply)(nil),   // 1: geo.GetGeolocationReply
 	(*Location)(nil),              // 2: geo.Location
-	(*ErrorResponse)(nil),         // 3: geo.ErrorResponse
+	(*Coordinate)(nil),            // 3: geo.Coordinate
+	(*ErrorResponse)(nil),         // 4: geo.ErrorResponse
 }
 var file_api_proto_geo_service_proto_depIdxs = []int32{
 	2, // 0: geo.GetGeolocationReply.Location:type_name -> geo.Location
-	0, // 1: geo.Geo.GetGeolocation:input_type -> geo.GetGeolocationRequest
-	1, // 2: geo.Geo.GetGeolocation:output_type -> geo.GetGeolocationReply
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 1: geo.GetGeolocationReply.Coordinate:type_name -> geo.Coordinate
+	0, // 2: geo.Geo.GetGeolocation:input_type -> geo.GetGeolocationRequest
+	1, // 3: geo.Geo.GetGeolocation:output_type -> geo.GetGeolocationReply
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_geo_service_proto_init() }
@@ -267,7 +337,7 @@ func file_api_proto_geo_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_geo_service_proto_rawDesc), len(file_api_proto_geo_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
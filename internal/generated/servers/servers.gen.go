@@ -14,13 +14,95 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for CourierSortField.
+const (
+	CourierSortFieldId    CourierSortField = "id"
+	CourierSortFieldName  CourierSortField = "name"
+	CourierSortFieldSpeed CourierSortField = "speed"
+)
+
+// Defines values for OrderSortField.
+const (
+	OrderSortFieldId        OrderSortField = "id"
+	OrderSortFieldCreatedAt OrderSortField = "createdAt"
+	OrderSortFieldVolume    OrderSortField = "volume"
+	OrderSortFieldStatus    OrderSortField = "status"
+)
+
+// Defines values for SortOrder.
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Defines values for TransportType.
+const (
+	Pedestrian TransportType = "pedestrian"
+	Bicycle    TransportType = "bicycle"
+	Car        TransportType = "car"
+)
+
+// Address defines model for Address.
+type Address struct {
+	// Apartment Квартира
+	Apartment string `json:"apartment"`
+
+	// City Город
+	City string `json:"city"`
+
+	// Country Страна
+	Country string `json:"country"`
+
+	// House Дом
+	House string `json:"house"`
+
+	// Street Улица
+	Street string `json:"street"`
+}
+
+// CancelOrder defines model for CancelOrder.
+type CancelOrder struct {
+	// Reason Причина отмены
+	Reason string `json:"reason"`
+}
+
+// CarriedOrder defines model for CarriedOrder.
+type CarriedOrder struct {
+	// Eta Ожидаемое время доставки, нет - до точки нет пути
+	Eta *time.Time `json:"eta,omitempty"`
+
+	// Id Идентификатор заказа
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+
+	// Steps Сколько шагов курьеру осталось до точки доставки, нет - до точки нет пути
+	Steps *float64 `json:"steps,omitempty"`
+
+	// StoragePlaceId Место хранения, в котором лежит заказ
+	StoragePlaceId openapi_types.UUID `json:"storagePlaceId"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// Coordinate defines model for Coordinate.
+type Coordinate struct {
+	// Latitude Широта
+	Latitude float64 `json:"latitude"`
+
+	// Longitude Долгота
+	Longitude float64 `json:"longitude"`
+}
+
 // Courier defines model for Courier.
 type Courier struct {
 	// Id Идентификатор
@@ -29,6 +111,62 @@ type Courier struct {
 
 	// Name Имя
 	Name string `json:"name"`
+
+	// Speed Скорость
+	Speed int `json:"speed"`
+
+	// Status Статус: OffDuty, OnShift, OnBreak или Deactivated
+	Status string `json:"status"`
+
+	// Transport Транспорт: pedestrian, bicycle, car или custom у курьеров, заведенных до появления транспорта
+	Transport string `json:"transport"`
+}
+
+// CourierDetails defines model for CourierDetails.
+type CourierDetails struct {
+	// Id Идентификатор
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+
+	// Name Имя
+	Name string `json:"name"`
+
+	// Orders Заказы, которые везет курьер
+	Orders []CarriedOrder `json:"orders"`
+
+	// Speed Скорость
+	Speed int `json:"speed"`
+
+	// Status Статус: OffDuty, OnShift, OnBreak или Deactivated
+	Status string `json:"status"`
+
+	// StoragePlaces Места хранения
+	StoragePlaces []StoragePlace `json:"storagePlaces"`
+
+	// Transport Транспорт: pedestrian, bicycle, car или custom у курьеров, заведенных до появления транспорта
+	Transport string `json:"transport"`
+}
+
+// CourierSortField Поле сортировки курьеров
+type CourierSortField string
+
+// CreatedOrder defines model for CreatedOrder.
+type CreatedOrder struct {
+	// Id Идентификатор
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+
+	// Status Статус
+	Status string `json:"status"`
+}
+
+// DeliveryWindow defines model for DeliveryWindow.
+type DeliveryWindow struct {
+	// From Начало окна доставки
+	From time.Time `json:"from"`
+
+	// To Конец окна доставки
+	To time.Time `json:"to"`
 }
 
 // Error defines model for Error.
@@ -40,6 +178,24 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// Item defines model for Item.
+type Item struct {
+	// GoodId Идентификатор товара
+	GoodId openapi_types.UUID `json:"goodId"`
+
+	// Id Идентификатор
+	Id openapi_types.UUID `json:"id"`
+
+	// Price Цена
+	Price float64 `json:"price"`
+
+	// Quantity Количество
+	Quantity int `json:"quantity"`
+
+	// Title Название
+	Title string `json:"title"`
+}
+
 // Location defines model for Location.
 type Location struct {
 	// X X
@@ -49,39 +205,289 @@ type Location struct {
 	Y int `json:"y"`
 }
 
+// NewAddress defines model for NewAddress.
+type NewAddress struct {
+	// Apartment Квартира
+	Apartment string `json:"apartment"`
+
+	// City Город
+	City string `json:"city"`
+
+	// Country Страна
+	Country string `json:"country"`
+
+	// House Дом
+	House string `json:"house"`
+
+	// Street Улица
+	Street string `json:"street"`
+}
+
 // NewCourier defines model for NewCourier.
 type NewCourier struct {
+	Coordinate *Coordinate `json:"coordinate,omitempty"`
+	Location   *Location   `json:"location,omitempty"`
+
 	// Name Имя
 	Name string `json:"name"`
 
-	// Speed Скорость
-	Speed int `json:"speed"`
+	// StoragePlaces Места хранения. Если не указаны, курьер получает сумку случайного объема
+	StoragePlaces *[]NewStoragePlace `json:"storagePlaces,omitempty"`
+	Transport     TransportType      `json:"transport"`
+}
+
+// NewOrder defines model for NewOrder.
+type NewOrder struct {
+	Address NewAddress `json:"address"`
+
+	// BasketId Идентификатор корзины, он же идентификатор заказа
+	BasketId openapi_types.UUID `json:"basketId"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// NewStoragePlace defines model for NewStoragePlace.
+type NewStoragePlace struct {
+	// Name Название
+	Name string `json:"name"`
+
+	// TotalVolume Общий объем
+	TotalVolume int `json:"totalVolume"`
 }
 
 // Order defines model for Order.
 type Order struct {
+	Address *Address `json:"address,omitempty"`
+
+	// CourierId Идентификатор назначенного курьера
+	CourierId *openapi_types.UUID `json:"courierId,omitempty"`
+
+	// CreatedAt Время создания
+	CreatedAt time.Time `json:"createdAt"`
+
 	// Id Идентификатор
-	Id       openapi_types.UUID `json:"id"`
-	Location Location           `json:"location"`
+	Id openapi_types.UUID `json:"id"`
+
+	// Items Товары
+	Items    []Item   `json:"items"`
+	Location Location `json:"location"`
+
+	// Status Статус: Created или Assigned
+	Status string `json:"status"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// OrderDetails defines model for OrderDetails.
+type OrderDetails struct {
+	Address *Address `json:"address,omitempty"`
+
+	// CancelReason Причина отмены
+	CancelReason *string `json:"cancelReason,omitempty"`
+
+	// CourierId Идентификатор курьера
+	CourierId      *openapi_types.UUID `json:"courierId,omitempty"`
+	DeliveryWindow *DeliveryWindow     `json:"deliveryWindow,omitempty"`
+
+	// History История статусов
+	History []StatusHistoryEntry `json:"history"`
+
+	// Id Идентификатор
+	Id openapi_types.UUID `json:"id"`
+
+	// IsLate Окно доставки закрылось, а заказ еще не доставлен
+	IsLate bool `json:"isLate"`
+
+	// Items Товары
+	Items    []Item   `json:"items"`
+	Location Location `json:"location"`
+
+	// Status Статус
+	Status string `json:"status"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// OrderSortField Поле сортировки заказов
+type OrderSortField string
+
+// ReplayedDeadLetters defines model for ReplayedDeadLetters.
+type ReplayedDeadLetters struct {
+	// Replayed Сколько сообщений отправлено повторно
+	Replayed int `json:"replayed"`
+}
+
+// SortOrder Направление сортировки
+type SortOrder string
+
+// StatusHistoryEntry defines model for StatusHistoryEntry.
+type StatusHistoryEntry struct {
+	// ChangedAt Время смены статуса
+	ChangedAt time.Time `json:"changedAt"`
+
+	// Status Статус
+	Status string `json:"status"`
+}
+
+// StoragePlace defines model for StoragePlace.
+type StoragePlace struct {
+	// FreeVolume Свободный объем
+	FreeVolume int `json:"freeVolume"`
+
+	// Id Идентификатор
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Название
+	Name string `json:"name"`
+
+	// TotalVolume Общий объем
+	TotalVolume int `json:"totalVolume"`
+
+	// UsedVolume Занятый объем
+	UsedVolume int `json:"usedVolume"`
+}
+
+// TransportType Транспорт курьера: pedestrian - пешком (скорость 1, объем до 10), bicycle - велосипед (2, до 20), car - машина (3, до 100)
+type TransportType string
+
+// UpdateCourier defines model for UpdateCourier.
+type UpdateCourier struct {
+	// Name Имя
+	Name      *string        `json:"name,omitempty"`
+	Transport *TransportType `json:"transport,omitempty"`
+}
+
+// GetCouriersParams defines parameters for GetCouriers.
+type GetCouriersParams struct {
+	// Busy true - только занятые курьеры, false - только свободные
+	Busy *bool `form:"busy,omitempty" json:"busy,omitempty"`
+
+	// Cursor Курсор следующей страницы из заголовка X-Next-Cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Размер страницы, по умолчанию 50
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// MaxSpeed Максимальная скорость
+	MaxSpeed *int `form:"maxSpeed,omitempty" json:"maxSpeed,omitempty"`
+
+	// MaxX Правая граница области
+	MaxX *int `form:"maxX,omitempty" json:"maxX,omitempty"`
+
+	// MaxY Верхняя граница области
+	MaxY *int `form:"maxY,omitempty" json:"maxY,omitempty"`
+
+	// MinSpeed Минимальная скорость
+	MinSpeed *int `form:"minSpeed,omitempty" json:"minSpeed,omitempty"`
+
+	// MinX Левая граница области
+	MinX *int `form:"minX,omitempty" json:"minX,omitempty"`
+
+	// MinY Нижняя граница области
+	MinY *int `form:"minY,omitempty" json:"minY,omitempty"`
+
+	// SortBy Поле сортировки, по умолчанию id
+	SortBy *CourierSortField `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Направление сортировки, по умолчанию asc
+	SortOrder *SortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+}
+
+// GetOrdersParams defines parameters for GetOrders.
+type GetOrdersParams struct {
+	// CourierId Идентификатор курьера
+	CourierId *openapi_types.UUID `form:"courierId,omitempty" json:"courierId,omitempty"`
+
+	// CreatedFrom Созданы не раньше
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Созданы раньше
+	CreatedTo *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// Cursor Курсор следующей страницы из заголовка X-Next-Cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Размер страницы, по умолчанию 50
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// SortBy Поле сортировки, по умолчанию id
+	SortBy *OrderSortField `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Направление сортировки, по умолчанию asc
+	SortOrder *SortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Status Статус: Created или Assigned
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
 }
 
 // CreateCourierJSONRequestBody defines body for CreateCourier for application/json ContentType.
 type CreateCourierJSONRequestBody = NewCourier
 
+// UpdateCourierJSONRequestBody defines body for UpdateCourier for application/json ContentType.
+type UpdateCourierJSONRequestBody = UpdateCourier
+
+// AddStoragePlaceJSONRequestBody defines body for AddStoragePlace for application/json ContentType.
+type AddStoragePlaceJSONRequestBody = NewStoragePlace
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = NewOrder
+
+// CancelOrderJSONRequestBody defines body for CancelOrder for application/json ContentType.
+type CancelOrderJSONRequestBody = CancelOrder
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Переотправить сообщения из DLQ
+	// (POST /api/v1/admin/dead-letters/replay)
+	ReplayDeadLetters(ctx echo.Context) error
 	// Получить всех курьеров
 	// (GET /api/v1/couriers)
-	GetCouriers(ctx echo.Context) error
+	GetCouriers(ctx echo.Context, params GetCouriersParams) error
 	// Добавить курьера
 	// (POST /api/v1/couriers)
 	CreateCourier(ctx echo.Context) error
+	// Деактивировать курьера
+	// (DELETE /api/v1/couriers/{courierId})
+	DeactivateCourier(ctx echo.Context, courierId openapi_types.UUID) error
+	// Получить курьера
+	// (GET /api/v1/couriers/{courierId})
+	GetCourier(ctx echo.Context, courierId openapi_types.UUID) error
+	// Изменить курьера
+	// (PATCH /api/v1/couriers/{courierId})
+	UpdateCourier(ctx echo.Context, courierId openapi_types.UUID) error
+	// Закончить смену курьера
+	// (DELETE /api/v1/couriers/{courierId}/shift)
+	EndCourierShift(ctx echo.Context, courierId openapi_types.UUID) error
+	// Начать смену курьера
+	// (POST /api/v1/couriers/{courierId}/shift)
+	StartCourierShift(ctx echo.Context, courierId openapi_types.UUID) error
+	// Закончить перерыв курьера
+	// (DELETE /api/v1/couriers/{courierId}/shift/break)
+	EndCourierBreak(ctx echo.Context, courierId openapi_types.UUID) error
+	// Начать перерыв курьера
+	// (POST /api/v1/couriers/{courierId}/shift/break)
+	StartCourierBreak(ctx echo.Context, courierId openapi_types.UUID) error
+	// Добавить место хранения
+	// (POST /api/v1/couriers/{courierId}/storage-places)
+	AddStoragePlace(ctx echo.Context, courierId openapi_types.UUID) error
+	// Убрать место хранения
+	// (DELETE /api/v1/couriers/{courierId}/storage-places/{storagePlaceId})
+	RemoveStoragePlace(ctx echo.Context, courierId openapi_types.UUID, storagePlaceId openapi_types.UUID) error
 	// Создать заказ
 	// (POST /api/v1/orders)
 	CreateOrder(ctx echo.Context) error
 	// Получить все незавершенные заказы
 	// (GET /api/v1/orders/active)
-	GetOrders(ctx echo.Context) error
+	GetOrders(ctx echo.Context, params GetOrdersParams) error
+	// Получить заказ
+	// (GET /api/v1/orders/{orderId})
+	GetOrder(ctx echo.Context, orderId openapi_types.UUID) error
+	// Отменить заказ
+	// (POST /api/v1/orders/{orderId}/cancel)
+	CancelOrder(ctx echo.Context, orderId openapi_types.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -89,12 +495,99 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// ReplayDeadLetters converts echo context to params.
+func (w *ServerInterfaceWrapper) ReplayDeadLetters(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ReplayDeadLetters(ctx)
+	return err
+}
+
 // GetCouriers converts echo context to params.
 func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
 	var err error
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCouriersParams
+	// ------------- Optional query parameter "busy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "busy", ctx.QueryParams(), &params.Busy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter busy: %s", err))
+	}
+
+	// ------------- Optional query parameter "minSpeed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minSpeed", ctx.QueryParams(), &params.MinSpeed)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter minSpeed: %s", err))
+	}
+
+	// ------------- Optional query parameter "maxSpeed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxSpeed", ctx.QueryParams(), &params.MaxSpeed)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter maxSpeed: %s", err))
+	}
+
+	// ------------- Optional query parameter "minX" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minX", ctx.QueryParams(), &params.MinX)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter minX: %s", err))
+	}
+
+	// ------------- Optional query parameter "minY" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minY", ctx.QueryParams(), &params.MinY)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter minY: %s", err))
+	}
+
+	// ------------- Optional query parameter "maxX" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxX", ctx.QueryParams(), &params.MaxX)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter maxX: %s", err))
+	}
+
+	// ------------- Optional query parameter "maxY" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxY", ctx.QueryParams(), &params.MaxY)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter maxY: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", ctx.QueryParams(), &params.SortBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortBy: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", ctx.QueryParams(), &params.SortOrder)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortOrder: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetCouriers(ctx)
+	err = w.Handler.GetCouriers(ctx, params)
 	return err
 }
 
@@ -107,71 +600,370 @@ func (w *ServerInterfaceWrapper) CreateCourier(ctx echo.Context) error {
 	return err
 }
 
-// CreateOrder converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
+// DeactivateCourier converts echo context to params.
+func (w *ServerInterfaceWrapper) DeactivateCourier(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateOrder(ctx)
+	err = w.Handler.DeactivateCourier(ctx, courierId)
 	return err
 }
 
-// GetOrders converts echo context to params.
-func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+// GetCourier converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCourier(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetOrders(ctx)
+	err = w.Handler.GetCourier(ctx, courierId)
 	return err
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-}
+// UpdateCourier converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateCourier(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateCourier(ctx, courierId)
+	return err
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+// EndCourierShift converts echo context to params.
+func (w *ServerInterfaceWrapper) EndCourierShift(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
 	}
 
-	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
-	router.POST(baseURL+"/api/v1/couriers", wrapper.CreateCourier)
-	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
-	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
-
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.EndCourierShift(ctx, courierId)
+	return err
 }
 
-type GetCouriersRequestObject struct {
-}
+// StartCourierShift converts echo context to params.
+func (w *ServerInterfaceWrapper) StartCourierShift(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
 
-type GetCouriersResponseObject interface {
-	VisitGetCouriersResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
 
-type GetCouriers200JSONResponse []Courier
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StartCourierShift(ctx, courierId)
+	return err
+}
 
-func (response GetCouriers200JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// EndCourierBreak converts echo context to params.
+func (w *ServerInterfaceWrapper) EndCourierBreak(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.EndCourierBreak(ctx, courierId)
+	return err
+}
+
+// StartCourierBreak converts echo context to params.
+func (w *ServerInterfaceWrapper) StartCourierBreak(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StartCourierBreak(ctx, courierId)
+	return err
+}
+
+// AddStoragePlace converts echo context to params.
+func (w *ServerInterfaceWrapper) AddStoragePlace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AddStoragePlace(ctx, courierId)
+	return err
+}
+
+// RemoveStoragePlace converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveStoragePlace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// ------------- Path parameter "storagePlaceId" -------------
+	var storagePlaceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "storagePlaceId", ctx.Param("storagePlaceId"), &storagePlaceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter storagePlaceId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveStoragePlace(ctx, courierId, storagePlaceId)
+	return err
+}
+
+// CreateOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateOrder(ctx)
+	return err
+}
+
+// GetOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+	var err error
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrdersParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "courierId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "courierId", ctx.QueryParams(), &params.CourierId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter createdFrom: %s", err))
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter createdTo: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", ctx.QueryParams(), &params.SortBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortBy: %s", err))
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", ctx.QueryParams(), &params.SortOrder)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sortOrder: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrders(ctx, params)
+	return err
+}
+
+// GetOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrder(ctx, orderId)
+	return err
+}
+
+// CancelOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CancelOrder(ctx, orderId)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(baseURL+"/api/v1/admin/dead-letters/replay", wrapper.ReplayDeadLetters)
+	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
+	router.POST(baseURL+"/api/v1/couriers", wrapper.CreateCourier)
+	router.DELETE(baseURL+"/api/v1/couriers/:courierId", wrapper.DeactivateCourier)
+	router.GET(baseURL+"/api/v1/couriers/:courierId", wrapper.GetCourier)
+	router.PATCH(baseURL+"/api/v1/couriers/:courierId", wrapper.UpdateCourier)
+	router.DELETE(baseURL+"/api/v1/couriers/:courierId/shift", wrapper.EndCourierShift)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/shift", wrapper.StartCourierShift)
+	router.DELETE(baseURL+"/api/v1/couriers/:courierId/shift/break", wrapper.EndCourierBreak)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/shift/break", wrapper.StartCourierBreak)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/storage-places", wrapper.AddStoragePlace)
+	router.DELETE(baseURL+"/api/v1/couriers/:courierId/storage-places/:storagePlaceId", wrapper.RemoveStoragePlace)
+	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
+	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
+	router.GET(baseURL+"/api/v1/orders/:orderId", wrapper.GetOrder)
+	router.POST(baseURL+"/api/v1/orders/:orderId/cancel", wrapper.CancelOrder)
+
+}
+
+type ReplayDeadLettersRequestObject struct {
+}
+
+type ReplayDeadLettersResponseObject interface {
+	VisitReplayDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type ReplayDeadLetters200JSONResponse ReplayedDeadLetters
+
+func (response ReplayDeadLetters200JSONResponse) VisitReplayDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayDeadLettersdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ReplayDeadLettersdefaultJSONResponse) VisitReplayDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCouriersRequestObject struct {
+	Params GetCouriersParams
+}
+
+type GetCouriersResponseObject interface {
+	VisitGetCouriersResponse(w http.ResponseWriter) error
+}
+
+type GetCouriers200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type GetCouriers200JSONResponse struct {
+	Body    []Courier
+	Headers GetCouriers200ResponseHeaders
+}
+
+func (response GetCouriers200JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCouriers400JSONResponse Error
+
+func (response GetCouriers400JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
@@ -213,76 +1005,644 @@ func (response CreateCourier400JSONResponse) VisitCreateCourierResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCourier409JSONResponse Error
+type CreateCourier409JSONResponse Error
+
+func (response CreateCourier409JSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourierdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateCourierdefaultJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeactivateCourierRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type DeactivateCourierResponseObject interface {
+	VisitDeactivateCourierResponse(w http.ResponseWriter) error
+}
+
+type DeactivateCourier200Response struct {
+}
+
+func (response DeactivateCourier200Response) VisitDeactivateCourierResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeactivateCourier404JSONResponse Error
+
+func (response DeactivateCourier404JSONResponse) VisitDeactivateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateCourier409JSONResponse Error
+
+func (response DeactivateCourier409JSONResponse) VisitDeactivateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeactivateCourierdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response DeactivateCourierdefaultJSONResponse) VisitDeactivateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCourierRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type GetCourierResponseObject interface {
+	VisitGetCourierResponse(w http.ResponseWriter) error
+}
+
+type GetCourier200JSONResponse CourierDetails
+
+func (response GetCourier200JSONResponse) VisitGetCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourier404JSONResponse Error
+
+func (response GetCourier404JSONResponse) VisitGetCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetCourierdefaultJSONResponse) VisitGetCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateCourierRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	Body      *UpdateCourierJSONRequestBody
+}
+
+type UpdateCourierResponseObject interface {
+	VisitUpdateCourierResponse(w http.ResponseWriter) error
+}
+
+type UpdateCourier200Response struct {
+}
+
+func (response UpdateCourier200Response) VisitUpdateCourierResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type UpdateCourier400JSONResponse Error
+
+func (response UpdateCourier400JSONResponse) VisitUpdateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCourier404JSONResponse Error
+
+func (response UpdateCourier404JSONResponse) VisitUpdateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCourier409JSONResponse Error
+
+func (response UpdateCourier409JSONResponse) VisitUpdateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateCourierdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response UpdateCourierdefaultJSONResponse) VisitUpdateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type EndCourierShiftRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type EndCourierShiftResponseObject interface {
+	VisitEndCourierShiftResponse(w http.ResponseWriter) error
+}
+
+type EndCourierShift200Response struct {
+}
+
+func (response EndCourierShift200Response) VisitEndCourierShiftResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type EndCourierShift404JSONResponse Error
+
+func (response EndCourierShift404JSONResponse) VisitEndCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EndCourierShift409JSONResponse Error
+
+func (response EndCourierShift409JSONResponse) VisitEndCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EndCourierShiftdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response EndCourierShiftdefaultJSONResponse) VisitEndCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type StartCourierShiftRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type StartCourierShiftResponseObject interface {
+	VisitStartCourierShiftResponse(w http.ResponseWriter) error
+}
+
+type StartCourierShift200Response struct {
+}
+
+func (response StartCourierShift200Response) VisitStartCourierShiftResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type StartCourierShift404JSONResponse Error
+
+func (response StartCourierShift404JSONResponse) VisitStartCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartCourierShift409JSONResponse Error
+
+func (response StartCourierShift409JSONResponse) VisitStartCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartCourierShiftdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response StartCourierShiftdefaultJSONResponse) VisitStartCourierShiftResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type EndCourierBreakRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type EndCourierBreakResponseObject interface {
+	VisitEndCourierBreakResponse(w http.ResponseWriter) error
+}
+
+type EndCourierBreak200Response struct {
+}
+
+func (response EndCourierBreak200Response) VisitEndCourierBreakResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type EndCourierBreak404JSONResponse Error
+
+func (response EndCourierBreak404JSONResponse) VisitEndCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EndCourierBreak409JSONResponse Error
+
+func (response EndCourierBreak409JSONResponse) VisitEndCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EndCourierBreakdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response EndCourierBreakdefaultJSONResponse) VisitEndCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type StartCourierBreakRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type StartCourierBreakResponseObject interface {
+	VisitStartCourierBreakResponse(w http.ResponseWriter) error
+}
+
+type StartCourierBreak200Response struct {
+}
+
+func (response StartCourierBreak200Response) VisitStartCourierBreakResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type StartCourierBreak404JSONResponse Error
+
+func (response StartCourierBreak404JSONResponse) VisitStartCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartCourierBreak409JSONResponse Error
+
+func (response StartCourierBreak409JSONResponse) VisitStartCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartCourierBreakdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response StartCourierBreakdefaultJSONResponse) VisitStartCourierBreakResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddStoragePlaceRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	Body      *AddStoragePlaceJSONRequestBody
+}
+
+type AddStoragePlaceResponseObject interface {
+	VisitAddStoragePlaceResponse(w http.ResponseWriter) error
+}
+
+type AddStoragePlace201Response struct {
+}
+
+func (response AddStoragePlace201Response) VisitAddStoragePlaceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type AddStoragePlace400JSONResponse Error
+
+func (response AddStoragePlace400JSONResponse) VisitAddStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddStoragePlace404JSONResponse Error
+
+func (response AddStoragePlace404JSONResponse) VisitAddStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddStoragePlace409JSONResponse Error
+
+func (response AddStoragePlace409JSONResponse) VisitAddStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddStoragePlacedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response AddStoragePlacedefaultJSONResponse) VisitAddStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveStoragePlaceRequestObject struct {
+	CourierId      openapi_types.UUID `json:"courierId"`
+	StoragePlaceId openapi_types.UUID `json:"storagePlaceId"`
+}
+
+type RemoveStoragePlaceResponseObject interface {
+	VisitRemoveStoragePlaceResponse(w http.ResponseWriter) error
+}
+
+type RemoveStoragePlace200Response struct {
+}
+
+func (response RemoveStoragePlace200Response) VisitRemoveStoragePlaceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RemoveStoragePlace404JSONResponse Error
+
+func (response RemoveStoragePlace404JSONResponse) VisitRemoveStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveStoragePlace409JSONResponse Error
+
+func (response RemoveStoragePlace409JSONResponse) VisitRemoveStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveStoragePlacedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RemoveStoragePlacedefaultJSONResponse) VisitRemoveStoragePlaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateOrderRequestObject struct {
+	Body *CreateOrderJSONRequestBody
+}
+
+type CreateOrderResponseObject interface {
+	VisitCreateOrderResponse(w http.ResponseWriter) error
+}
+
+type CreateOrder200JSONResponse CreatedOrder
+
+func (response CreateOrder200JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder201JSONResponse CreatedOrder
+
+func (response CreateOrder201JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder400JSONResponse Error
+
+func (response CreateOrder400JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder409JSONResponse Error
+
+func (response CreateOrder409JSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrderdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateOrderdefaultJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrdersRequestObject struct {
+	Params GetOrdersParams
+}
+
+type GetOrdersResponseObject interface {
+	VisitGetOrdersResponse(w http.ResponseWriter) error
+}
+
+type GetOrders200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type GetOrders200JSONResponse struct {
+	Body    []Order
+	Headers GetOrders200ResponseHeaders
+}
+
+func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrders400JSONResponse Error
+
+func (response GetOrders400JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrdersdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrderRequestObject struct {
+	OrderId openapi_types.UUID `json:"orderId"`
+}
+
+type GetOrderResponseObject interface {
+	VisitGetOrderResponse(w http.ResponseWriter) error
+}
+
+type GetOrder200JSONResponse OrderDetails
+
+func (response GetOrder200JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrder404JSONResponse Error
 
-func (response CreateCourier409JSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+func (response GetOrder404JSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCourierdefaultJSONResponse struct {
+type GetOrderdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response CreateCourierdefaultJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+func (response GetOrderdefaultJSONResponse) VisitGetOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateOrderRequestObject struct {
+type CancelOrderRequestObject struct {
+	OrderId openapi_types.UUID `json:"orderId"`
+	Body    *CancelOrderJSONRequestBody
 }
 
-type CreateOrderResponseObject interface {
-	VisitCreateOrderResponse(w http.ResponseWriter) error
+type CancelOrderResponseObject interface {
+	VisitCancelOrderResponse(w http.ResponseWriter) error
 }
 
-type CreateOrder201Response struct {
+type CancelOrder200Response struct {
 }
 
-func (response CreateOrder201Response) VisitCreateOrderResponse(w http.ResponseWriter) error {
-	w.WriteHeader(201)
+func (response CancelOrder200Response) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
 	return nil
 }
 
-type CreateOrderdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+type CancelOrder400JSONResponse Error
 
-func (response CreateOrderdefaultJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+func (response CancelOrder400JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response.Body)
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetOrdersRequestObject struct {
-}
+type CancelOrder404JSONResponse Error
 
-type GetOrdersResponseObject interface {
-	VisitGetOrdersResponse(w http.ResponseWriter) error
+func (response CancelOrder404JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetOrders200JSONResponse []Order
+type CancelOrder409JSONResponse Error
 
-func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+func (response CancelOrder409JSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetOrdersdefaultJSONResponse struct {
+type CancelOrderdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+func (response CancelOrderdefaultJSONResponse) VisitCancelOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
@@ -291,18 +1651,54 @@ func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.Respo
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Переотправить сообщения из DLQ
+	// (POST /api/v1/admin/dead-letters/replay)
+	ReplayDeadLetters(ctx context.Context, request ReplayDeadLettersRequestObject) (ReplayDeadLettersResponseObject, error)
 	// Получить всех курьеров
 	// (GET /api/v1/couriers)
 	GetCouriers(ctx context.Context, request GetCouriersRequestObject) (GetCouriersResponseObject, error)
 	// Добавить курьера
 	// (POST /api/v1/couriers)
 	CreateCourier(ctx context.Context, request CreateCourierRequestObject) (CreateCourierResponseObject, error)
+	// Деактивировать курьера
+	// (DELETE /api/v1/couriers/{courierId})
+	DeactivateCourier(ctx context.Context, request DeactivateCourierRequestObject) (DeactivateCourierResponseObject, error)
+	// Получить курьера
+	// (GET /api/v1/couriers/{courierId})
+	GetCourier(ctx context.Context, request GetCourierRequestObject) (GetCourierResponseObject, error)
+	// Изменить курьера
+	// (PATCH /api/v1/couriers/{courierId})
+	UpdateCourier(ctx context.Context, request UpdateCourierRequestObject) (UpdateCourierResponseObject, error)
+	// Закончить смену курьера
+	// (DELETE /api/v1/couriers/{courierId}/shift)
+	EndCourierShift(ctx context.Context, request EndCourierShiftRequestObject) (EndCourierShiftResponseObject, error)
+	// Начать смену курьера
+	// (POST /api/v1/couriers/{courierId}/shift)
+	StartCourierShift(ctx context.Context, request StartCourierShiftRequestObject) (StartCourierShiftResponseObject, error)
+	// Закончить перерыв курьера
+	// (DELETE /api/v1/couriers/{courierId}/shift/break)
+	EndCourierBreak(ctx context.Context, request EndCourierBreakRequestObject) (EndCourierBreakResponseObject, error)
+	// Начать перерыв курьера
+	// (POST /api/v1/couriers/{courierId}/shift/break)
+	StartCourierBreak(ctx context.Context, request StartCourierBreakRequestObject) (StartCourierBreakResponseObject, error)
+	// Добавить место хранения
+	// (POST /api/v1/couriers/{courierId}/storage-places)
+	AddStoragePlace(ctx context.Context, request AddStoragePlaceRequestObject) (AddStoragePlaceResponseObject, error)
+	// Убрать место хранения
+	// (DELETE /api/v1/couriers/{courierId}/storage-places/{storagePlaceId})
+	RemoveStoragePlace(ctx context.Context, request RemoveStoragePlaceRequestObject) (RemoveStoragePlaceResponseObject, error)
 	// Создать заказ
 	// (POST /api/v1/orders)
 	CreateOrder(ctx context.Context, request CreateOrderRequestObject) (CreateOrderResponseObject, error)
 	// Получить все незавершенные заказы
 	// (GET /api/v1/orders/active)
 	GetOrders(ctx context.Context, request GetOrdersRequestObject) (GetOrdersResponseObject, error)
+	// Получить заказ
+	// (GET /api/v1/orders/{orderId})
+	GetOrder(ctx context.Context, request GetOrderRequestObject) (GetOrderResponseObject, error)
+	// Отменить заказ
+	// (POST /api/v1/orders/{orderId}/cancel)
+	CancelOrder(ctx context.Context, request CancelOrderRequestObject) (CancelOrderResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -317,10 +1713,35 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// ReplayDeadLetters operation middleware
+func (sh *strictHandler) ReplayDeadLetters(ctx echo.Context) error {
+	var request ReplayDeadLettersRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayDeadLetters(ctx.Request().Context(), request.(ReplayDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayDeadLetters")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ReplayDeadLettersResponseObject); ok {
+		return validResponse.VisitReplayDeadLettersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetCouriers operation middleware
-func (sh *strictHandler) GetCouriers(ctx echo.Context) error {
+func (sh *strictHandler) GetCouriers(ctx echo.Context, params GetCouriersParams) error {
 	var request GetCouriersRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetCouriers(ctx.Request().Context(), request.(GetCouriersRequestObject))
 	}
@@ -369,10 +1790,254 @@ func (sh *strictHandler) CreateCourier(ctx echo.Context) error {
 	return nil
 }
 
+// DeactivateCourier operation middleware
+func (sh *strictHandler) DeactivateCourier(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request DeactivateCourierRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeactivateCourier(ctx.Request().Context(), request.(DeactivateCourierRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeactivateCourier")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeactivateCourierResponseObject); ok {
+		return validResponse.VisitDeactivateCourierResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCourier operation middleware
+func (sh *strictHandler) GetCourier(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request GetCourierRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCourier(ctx.Request().Context(), request.(GetCourierRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCourier")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetCourierResponseObject); ok {
+		return validResponse.VisitGetCourierResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateCourier operation middleware
+func (sh *strictHandler) UpdateCourier(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request UpdateCourierRequestObject
+
+	request.CourierId = courierId
+
+	var body UpdateCourierJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateCourier(ctx.Request().Context(), request.(UpdateCourierRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateCourier")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateCourierResponseObject); ok {
+		return validResponse.VisitUpdateCourierResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// EndCourierShift operation middleware
+func (sh *strictHandler) EndCourierShift(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request EndCourierShiftRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.EndCourierShift(ctx.Request().Context(), request.(EndCourierShiftRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EndCourierShift")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(EndCourierShiftResponseObject); ok {
+		return validResponse.VisitEndCourierShiftResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// StartCourierShift operation middleware
+func (sh *strictHandler) StartCourierShift(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request StartCourierShiftRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.StartCourierShift(ctx.Request().Context(), request.(StartCourierShiftRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StartCourierShift")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(StartCourierShiftResponseObject); ok {
+		return validResponse.VisitStartCourierShiftResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// EndCourierBreak operation middleware
+func (sh *strictHandler) EndCourierBreak(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request EndCourierBreakRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.EndCourierBreak(ctx.Request().Context(), request.(EndCourierBreakRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EndCourierBreak")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(EndCourierBreakResponseObject); ok {
+		return validResponse.VisitEndCourierBreakResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// StartCourierBreak operation middleware
+func (sh *strictHandler) StartCourierBreak(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request StartCourierBreakRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.StartCourierBreak(ctx.Request().Context(), request.(StartCourierBreakRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StartCourierBreak")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(StartCourierBreakResponseObject); ok {
+		return validResponse.VisitStartCourierBreakResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// AddStoragePlace operation middleware
+func (sh *strictHandler) AddStoragePlace(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request AddStoragePlaceRequestObject
+
+	request.CourierId = courierId
+
+	var body AddStoragePlaceJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddStoragePlace(ctx.Request().Context(), request.(AddStoragePlaceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddStoragePlace")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(AddStoragePlaceResponseObject); ok {
+		return validResponse.VisitAddStoragePlaceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RemoveStoragePlace operation middleware
+func (sh *strictHandler) RemoveStoragePlace(ctx echo.Context, courierId openapi_types.UUID, storagePlaceId openapi_types.UUID) error {
+	var request RemoveStoragePlaceRequestObject
+
+	request.CourierId = courierId
+	request.StoragePlaceId = storagePlaceId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveStoragePlace(ctx.Request().Context(), request.(RemoveStoragePlaceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveStoragePlace")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RemoveStoragePlaceResponseObject); ok {
+		return validResponse.VisitRemoveStoragePlaceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // CreateOrder operation middleware
 func (sh *strictHandler) CreateOrder(ctx echo.Context) error {
 	var request CreateOrderRequestObject
 
+	var body CreateOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.CreateOrder(ctx.Request().Context(), request.(CreateOrderRequestObject))
 	}
@@ -393,9 +2058,11 @@ func (sh *strictHandler) CreateOrder(ctx echo.Context) error {
 }
 
 // GetOrders operation middleware
-func (sh *strictHandler) GetOrders(ctx echo.Context) error {
+func (sh *strictHandler) GetOrders(ctx echo.Context, params GetOrdersParams) error {
 	var request GetOrdersRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetOrders(ctx.Request().Context(), request.(GetOrdersRequestObject))
 	}
@@ -415,24 +2082,131 @@ func (sh *strictHandler) GetOrders(ctx echo.Context) error {
 	return nil
 }
 
+// GetOrder operation middleware
+func (sh *strictHandler) GetOrder(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request GetOrderRequestObject
+
+	request.OrderId = orderId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrder(ctx.Request().Context(), request.(GetOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetOrderResponseObject); ok {
+		return validResponse.VisitGetOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CancelOrder operation middleware
+func (sh *strictHandler) CancelOrder(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request CancelOrderRequestObject
+
+	request.OrderId = orderId
+
+	var body CancelOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelOrder(ctx.Request().Context(), request.(CancelOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelOrder")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CancelOrderResponseObject); ok {
+		return validResponse.VisitCancelOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVT28bRRT/KqsHx1Vtt1zYIwEhpIgeuIAQh8V+caby7iyz47RWtFLsQBspUXPhgHoo",
-	"KnyBjcnKi0Pcr/DeN0IzEyduPHFcEVURF8u7O/Pm9++92YW2TDKZYqpziHYhb29jEtu/G7KvBCrzN1My",
-	"Q6UF2g+iY347mLeVyLSQKURAv9EpVXTOI6r5Z6ppSiWPaMZ7EMKWVEmsIYJ+X3QgBD3IECLItRJpF4oQ",
-	"erIdu0K78LHCLYjgo8YVsMYFqsbmfF0RQhon6MXxDx8vn1GEoPCnvlDYgeh7sDBshYXDf7jcJX98gm1t",
-	"TvlCKemRoC07vsNf0YxOA5rxAdV0QlOqF9mLVD96eAVNpBq7qMwpCeZ53PVV/IMqmvKQR9erruZn8V3V",
-	"9THbXND8XXLPlnF8a4qJVCT9BKKmj8JgedN3t2y6hvkZmCo+qF/j0xvDeEsMEpFuYtrV2xC1PMHLM0Rf",
-	"mt/Q1GSXZkZ6Plok0rqVyEWuXG0fn8eqc1/7ytcnKxrErBfplvQAf80jGlPFL6ikyuR3QmXA+/zCPU15",
-	"n/f4iCqjMo3DwPDkIb01n+2iParNHn5ONb80n60ZVNKYZjQN330z5X0jgNA9A++bp3G3iyr4HHtiB9UA",
-	"QthBlTtkrQfNB02jjswwjTMBETyyr0LIYr1trWjEmWjstBptFzv7rovaQ/N3mtHEQjrjY0ftrX0wTGsT",
-	"noDGPKSKf1kiDRaDsuJ+1YEIvkS9MT/RGJFnMs1dOB42m27wpBpTCyTOsp5wzjSe5M5kZ6gNk8Ykv833",
-	"eVcVl8bGSsUD5+s1on9emHNA53xIf5t55AwegV28Ffd7+r0grkLm5q4Px+vLMVjauOb9JInVYO7FesIX",
-	"IWQyX9PPU5rRiU3ZRdnFauWSiRsKY41zaV0/Ya4/k53BncmzMBF9Gr26AgjFUpBaHtqr3f3kPcP3n50N",
-	"aEwlnVFNp24CUO1wfPrBcfCha2g6N3OYaj4O6MSOpnMzsAI6oxn9ZSdzfW864dfVkTWr5yNOmsvIJmPt",
-	"juChfWWsscUnVBoQNAl4GPBzquiMj/hlwCOjkL26bNtR6QS8oWXctXgHcb0XFry5QSOP+I24rcUO3sEl",
-	"E9iUTqzzFe/xgc2s0ahagMCHvpvnsQvCh7h3nNP/61tnbSeKoij+DQAA//8JDQamhg0AAA==",
+	"H4sIAAAAAAACA+1dbW/b1hX+K4S2Dy1AR3baAqu/JXG7BQiarem2FEU+0NK1zVYiVZJKbAQGYntp0zmL",
+	"ga5DgmxN5gXY9lFxrFiJbeUvkP9o55x7SV6SlxRlyZncCihivfHec889L895ube3KzW72bItZnluZf52",
+	"xa2tsKZBLy/U6w5z6WXLsVvM8UxG74yW4XhNeALf1Jlbc8yWZ9pWZb7iP/b3/E5wJ9j0e8Edv1PRK95a",
+	"i8E3rueY1nJlXa/UTG9N8eRf/T480ff3lc/YbctzVI/tBps4kX+snmzFbrtM8dgPMNOR6gF4xZhqZc/8",
+	"Q1jTN6pp4DGHfd02HVavzH8RESuWGo0ZUqNLHLwRDWYvfslqHpJwybBqrHHVqTMny3yHGS4SlKHvKbCh",
+	"F3zr95AVGjBz0z/yu/5xsA0TNk3rCrOWvZXK/Nwg8sUMasocx2T1HNKYZyjoeuK/BJr2YYu6QFDf72r+",
+	"HpAKb4IdDT7vBxtAagfk5rXf0zWgvhtsajP0lQbf9GFN8E34hf8m2ELpgkUt2U7TgK2q1A2PzXhmk6k2",
+	"1KwriHoEoyNvUEz/BOS9BqHFqe5o/gHQAm/xrzxHuw0DKYZv2DWDj3q78kuHLcGXv6jGOlUVClW9Ev6O",
+	"ZIy1XJUsw8R9/zC4j3+14B5Q8QI+2NP817DoO/B5F/7d0iKeHdKr+1lejYetdnuxIfHUajcXYd9pAbZj",
+	"LLPfNowau6zi7z9gUJwfpror1BPYDczeAVJwPSSfXOGPNFhHF6UE6YjYX4b5N+1Gu8mUQvc8+DPKWPyU",
+	"aXlsGclPiTuNnFpQNLK0v0p9sG2nblogflltaMBjXruuou6/ZByRAx0lv5vGqtlsNyvzH86S6vI3M/gu",
+	"sxUN21rOmweN3CGK0OCZ5n6VmIrepuZK8S1an0yDmkltMBoKezGcZp6WMlqGUoIeoX1SOogWY/U85aVt",
+	"BcEP7isED8XM8Nqu2ovhOkEHN+a1q0tLC21vTdeuWtdWzCUPX1wEq/yVBvwAJ6QtMKPmmTdB7JRc8BzD",
+	"clu2o/Ji/+LaGGz4b5DaYHNeazH4DTxsWLq2aNbWag2mazXDCWertUE7mhraHckMoV3SubruwYbRpqGz",
+	"Ce5yE0Pj78B3h6Hma6Gnjicv4Uxpo2mPpO2NOBluh7zoAhlcABdlNtyfiija6IdV4vQwNKLBti4Z22Cb",
+	"vC8s6oAbfWk/YXzTY013EL0JCLAeEQWfGmtnQz1kW+8W+K5OxneV5dE1aQYVj36yCppVRklTE9orb0Ek",
+	"yAWaew0G+9hkDZVoPSU319WA7H4UevQ58smwBGZjFvo4FeU3FOJyCUTLy8W8k2E4yihOuY3MmljVpiyw",
+	"hnmTOWt/NK26fSvLlSXHbiqo+RFo+ZbDVsCwwBaKVFJYtTSw92xl/NknRPvNyBOkuENLoklVDPnIcWyF",
+	"dNRsJS5DIvcRxd8D4XiepgkM4nvnlfaxCeE4qI3KaoC8vcZFpkcdFKkSfgvHVa3sMhi87MKWbbt+ebjA",
+	"Cv/w5EC5wGr8mtVyzJqKef8mA9gpF/p83TYsT53BeEx2CGNw7kD2/L5yG+HxBlOrB5jrPfI4Pb9bTmHF",
+	"ToSjhquUCFXt6hXJyCR3djVL2PWKFBrMqlak4MbnAx5KrWW1gqOoSP2E3ZpmonIzUcMkdkbNS8FO5MZy",
+	"tUQkXAgg41+eDkwuZMko8O+c5v8NMMahyJoA4AoTRYir9ATUIHjlH8IH6PAQbMOgW/4R/gaBSvjNKxip",
+	"jyE6+iuRs6CNLQU0YUeGwppFY30W/vAzHCEtOwImFUdZQE4OTDJiDR6wnlDXYbhFw/2KeUO6GRFmHGAe",
+	"lDYF8IDmv8TApze2pF+pvFNk/eYGWr9opXrEqWiSHEYn9j3D7xwVyXqYAcri2Z7R+EPRar+DgV5Jwjvc",
+	"wkOxkqZRrXc0qZJEqsbt17AydUx8Oyb4SmFUqLNycFFKcmo8mrigsuvfR2lxjGRgwn2+UWTVTiPZXQqG",
+	"hXYogzgFmqP6QilrRVBSYaJOK+KZ10TsFgbKF1zXXLbY6aaSpQiKc0UKgaOsciwGueKem6s6gdRTQenT",
+	"E1WOVADoJCo0tKbUMzFm0WpTESniLRM9vQqoPeKlCVo16losMSJBUDLJg5v6Gz7LRwSsFLJ9CgrpXhEo",
+	"KyOoGO/2M/Gu8G6U/hMFI3CLHcnpabAr36GHRFSTeJxyQzEVi7bdYIZ1xu3CKVeS0rquMAhiD2MhzbUC",
+	"J897xZBGkfWK7Y9EaCbhE7PnU9ZqGGusvsCM+hXmeSLtnC5N8x8NrmyiovUJPBC0JgAB9L8hvB1mJHmK",
+	"0t8TunqsjKYztWtBgoqhyMsISSiQUXL6Xg5nJVYabq2i00hKpiksRDZuWjGs5TJ4QFjkpLnqlAYGY8kQ",
+	"RrIdU63kcyE0XYKYMxdR7mLSxH+OwTeuNo0ss5mH8dvX0tB5PGA5uyQIxeu5ozykYHMHNmwgcwry9DKl",
+	"iQl1eXtUe5uMEkuUL1KeX65mYDfCG/j4HhmHI+0deCpRJNLmdGmJvGwxN/tuVAXBAbC2QV4NWPsG6xza",
+	"O+d1/tPz+FOsk8DPIKimvCginHfe08OxZt+V1DmmDD4UU6CwG45SvX/fQo3LzYiMlqUYIWxP7Rl+ZFpL",
+	"qmT5E0pSdqMUBfoMjRIT6eqgKCbtY26E9myTfgQICp8JvgFFe5BEDn3/tZ6CIsFWlKacr1y7ZSyDmGoh",
+	"cENHBF6FUzZ3bvbcLFU4W8wyWiZ89B59pFdahrdC/K3C59Wbc1WjDqys1sExzTS4Z6pyL0BbYrsqy/of",
+	"7DUJF31MjrIPy+qBs0EhwQTQDvapLFz5XcZZwTcXKVy/ZFtLptOk8IL6Z8BuHaK1gVc0cA9LbfjkHbI/",
+	"5NH28OON4K5k4SgxjpO/rtCCHUILiK+F25WdLuo0bLflciE7PzvLs2+g9DwLarRaDZMDjuqXAu9zWRkk",
+	"SSofT9KTTj8KEbgXWWghRpscti8Z7YY3Nqp4ZUVFx5Oo0NEhuXfbzaaBPhaRUZd8pwwqemRRFLuJMow7",
+	"TYOEUiWiHF7sUGZhn1KIvkewZkf0UEUpPz4ZiNMGfHNXVYRMbvSvUZzEjCjjDhgPDrK+SE/sOW00fCQ1",
+	"IaASOUjuFrrJdrFtXVsyGm7mGSAt6WvRp5k4w9dtro/cglUW2+5aRfYlNJwu7V86OljXFVnVHjnOIyz/",
+	"AQWYSyFck+4KUFEACn5NVJYHUyH5PwUVGAhtnJgOY3U8dPwdhG+PZvZfiBwzJfTJXIAR6ZDR7OWz4/rI",
+	"JPwITHhJMnNiIj4fmYinQjdPzAljdXROfE9qcncUXhirY+BFQTSnk23RqICAGvytoPCBRqhORZMLkODi",
+	"AL0tLtOk+i7UQlQ2aspfAQ+i8pbA47UTryIO+VTkPyYzucHL0xu0gn346AE5h1cUasXCALEXeQqytS9o",
+	"s/jaOtr1mU/Yqjdzqe0AyTmLqYVfDpaSOPrKUPxPikKOqMKUJi+fxR/M5hDVMJumV0xT1CH6wezsgNT+",
+	"jRHBSbn+M4G3M/mi4ZCKXlkBmCMcfHL/5keVE50mwnof/QtThoiQ8qtvKFqhQai2mB6AHHG+UOA63x8j",
+	"7CsFsDSKfA95Iz/CfbCFkwP2yqIu7AFRBwQKMLdPpj9GjpkMdhLB8VJDKJ1cpSCavGjX18bGHqkAr+LR",
+	"Y6mbcz2ji3OqToJCKD8pUvb+7IdvnQ5gB6H5uPqvwXc9qgJCeKiR9X9BuaXJ0YQfikVWFd9Ub0f1nHUu",
+	"IBBEM2Uycpv0Y58f00iMzB0jWbDnZPq2z2myNGrIMKnEDg6Kzqh0CY5vYtwdYYUOluufB9t8AVSQQHf2",
+	"UljtpNLFnb6x4hUGT8NUqMhhYrpBcuJR7Uv2mRiRyRZ7QIoxz08OqZvvvwVZk/eQ78Ux9awQD6eqOZRq",
+	"KoVdqab6CBmHpF6GevcCj43BK0xzvdIIP2KvaLrBSUvWjUhPs+cHqKEmOkRQkMo4y8o4FklJnToZPpk2",
+	"IVo+mTAvozUgHrWVsnrDE4Q9XlfLVUbSiB4PuUhH6Cddav5RJI2SmpAsEJwZZRg/bk0yYj1ZmUIa18fi",
+	"EScFrU4981nyzI/S2j0caK66eAatEDrvRpnvbhY6Y1pBqu+XAc4HomB1HNnC6PmtgZD5I6sepvaI8Clg",
+	"nqrlJKrlw0Ihz/p+dV6nOGo95vonRtWl1JxGjb7U4osdOFvJFuBO8IAyezuSclK7W1LVrnmG402Vbaps",
+	"E69s4mDmIDUr5Qqri3gWuziXxAE59UV8l+cWI5BOcedeVl8LXBsdB59q21TbzohrS4p6efe2K1qr8pxb",
+	"ctx5jUe6PI0jeS6R06FNTR9b44fdeFfTPh+3q/H7BBToUnZ5UyWcKuHZcHkD1G+g2+P9zjOt6EhpjrrG",
+	"9ZkoEZW62Oso994srrD8RFjOkdGkKl6o1xON2D/j/FPmmGyZDNTcNAM1NUX/n7JtvhUY1hhVbydvtiuu",
+	"8T5DXtGEdGB9K4Mp8gk7p8V3/pW+4k/HZiXRHi2qvdSUeUAd8ulW6KZ9k505i6YPQ9VR4c1TWRIztxb+",
+	"7CAQP9db6DbTpinYnhqnIYzTM1lBSxqm+F660u1W8Yl3PpN0NnUPoRFgtWNsuQM781Q+FMjF8ED0ofaB",
+	"i/jfJj8vhLc+hFcrYCDDP0eBeckPunb4lWj8s2PRuioO3ouGPR4vvUpQ1NOI9nQKA4zZy+QYx+ERD57k",
+	"Txw0PtJAx3RKdOxTef4FFQl6abpmNJRVdbuZ3B17CqAp7JzNCsxD6ZbWUqW88dTT5avYCskK9wI7ifzD",
+	"xJZowV+oC0PIhyw7dBMPjCrA39uhedqDp9w/0mJ8hzsVKbFCxQZrUOgl+mffGu/mWEmF+a1SaxwbwwEm",
+	"DszERZLA6HvhVZKpNJKqFegqdwWDoFrJWzuUpwTCQ8kjNdYPixXT3f1KsJihY3i0uBsLOubpyEfxE7b3",
+	"cR/yyOEc/JjfWViGoMKLEAdQVZ6gz+xTIWfCjs6kLm6YHpyZHpyZgIMzOTc2T4/NTI/NnMTXKwDHbfor",
+	"kkwnxxwHEgikI/NIV3RkgP9/L+Bhjuz4cXpxm1MkFan7nNSoZJT8UfK+QEVqRrBiYluTE1eMTWpjchwO",
+	"nKG25EJMHqlIld/JNlSOJL6ZLTNXqkv/1eAbvVLpBOl/OXQm1GL8+Q6ZBSoZKLwlb9rOfKqaPs3WlrRG",
+	"T3ItBEGg/wFAyz6K7m0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
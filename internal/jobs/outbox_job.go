@@ -3,9 +3,11 @@ package jobs
 import (
 	"context"
 	"delivery/internal/adapters/out/postgres/outboxrepo"
+	"delivery/internal/pkg/backoff"
 	"delivery/internal/pkg/ddd"
 	"delivery/internal/pkg/errs"
 	"delivery/internal/pkg/outbox"
+	"fmt"
 	"github.com/labstack/gommon/log"
 	"github.com/robfig/cron/v3"
 	"time"
@@ -13,6 +15,22 @@ import (
 
 var _ cron.Job = &OutboxJob{}
 
+const (
+	outboxBatchSize = 20
+
+	// Неудачное сообщение отправляется повторно: пауза между попытками удваивается
+	// от outboxMinBackoff до outboxMaxBackoff. Сообщение, не ушедшее за outboxMaxAttempts попыток
+	// (около суток простоя брокера), откладывается для ручного разбора
+	outboxMinBackoff  = 5 * time.Second
+	outboxMaxBackoff  = 10 * time.Minute
+	outboxMaxAttempts = 150
+)
+
+// outboxBackoff возвращает паузу перед следующей отправкой после attempts неудачных попыток
+func outboxBackoff(attempts int) time.Duration {
+	return backoff.Exponential(attempts, outboxMinBackoff, outboxMaxBackoff)
+}
+
 type OutboxJob struct {
 	outboxRepository outboxrepo.OutboxRepository
 	registry         outbox.EventRegistry
@@ -41,35 +59,36 @@ func NewOutboxJob(outboxRepository outboxrepo.OutboxRepository,
 func (j *OutboxJob) Run() {
 	ctx := context.Background()
 
-	// Получаем не отправленные Outbox Events
-	outboxMessages, err := j.outboxRepository.GetNotPublishedMessages()
-	if err != nil {
-		log.Error(err)
-	}
-
-	// Перебираем в цикле
-	for _, outboxMessage := range outboxMessages {
-		// Приводим Outbox Message -> Domain Event
-		domainEvent, err := j.registry.DecodeDomainEvent(outboxMessage)
+	// Захватываем пачки неотправленных Outbox Events, пока они отправляются. Пока транзакция открыта,
+	// другие реплики их не видят, а следующее событие агрегата попадает в пачку только после предыдущего
+	for {
+		published, err := j.outboxRepository.PublishBatch(ctx, outboxBatchSize, outboxMaxAttempts,
+			outboxBackoff, j.publish)
 		if err != nil {
 			log.Error(err)
-			continue
+			return
 		}
-		log.Info(domainEvent)
-
-		err = j.mediatr.Publish(ctx, domainEvent)
-		if err != nil {
-			log.Error(err)
-			continue
+		if published == 0 {
+			return
 		}
+		log.Infof("outbox: published %d messages", published)
+	}
+}
 
-		// Если ошибок нет, помечаем Outbox Message как отправленное и сохраняем в БД
-		now := time.Now().UTC()
-		outboxMessage.ProcessedAtUtc = &now
-		err = j.outboxRepository.Update(ctx, outboxMessage)
-		if err != nil {
-			log.Error(err)
-			continue
-		}
+func (j *OutboxJob) publish(ctx context.Context, outboxMessage *outbox.Message) error {
+	// Приводим Outbox Message -> Domain Event
+	// Сообщение, которое не декодируется сейчас, не декодируется и потом, поэтому повторять его незачем
+	domainEvent, err := j.registry.DecodeDomainEvent(outboxMessage)
+	if err != nil {
+		log.Error(err)
+		return fmt.Errorf("%w: %v", outboxrepo.ErrUndeliverable, err)
+	}
+	log.Info(domainEvent)
+
+	err = j.mediatr.Publish(ctx, domainEvent)
+	if err != nil {
+		log.Error(err)
+		return err
 	}
+	return nil
 }
@@ -0,0 +1,329 @@
+package jobs
+
+import (
+	"context"
+	"delivery/internal/adapters/out/postgres/outboxrepo"
+	"delivery/internal/core/domain/model/kernel"
+	"delivery/internal/core/domain/model/order"
+	"delivery/internal/pkg/ddd"
+	"delivery/internal/pkg/outbox"
+	"delivery/internal/pkg/testcnts"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	postgresgorm "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func setupTest(t *testing.T) (context.Context, *gorm.DB, error) {
+	ctx := context.Background()
+	postgresContainer, dsn, err := testcnts.StartPostgresContainer(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := gorm.Open(postgresgorm.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&outbox.Message{})
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := postgresContainer.Terminate(ctx)
+		assert.NoError(t, err)
+	})
+
+	return ctx, db, nil
+}
+
+// recordingMediatr запоминает, какие события, сколько раз и в каком порядке были отправлены
+type recordingMediatr struct {
+	mu        sync.Mutex
+	published map[uuid.UUID]int
+	sequence  []uuid.UUID
+	delay     time.Duration
+	err       error
+	// Если задано, ошибкой err завершается отправка только событий с этим именем
+	failName string
+}
+
+func newRecordingMediatr() *recordingMediatr {
+	return &recordingMediatr{published: make(map[uuid.UUID]int)}
+}
+
+func (m *recordingMediatr) Subscribe(_ ddd.EventHandler, _ ...ddd.DomainEvent) {}
+
+func (m *recordingMediatr) Publish(_ context.Context, event ddd.DomainEvent) error {
+	// Пауза растягивает транзакцию, чтобы реплики гарантированно пересеклись
+	time.Sleep(m.delay)
+	if m.err != nil && (m.failName == "" || m.failName == event.GetName()) {
+		return m.err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.published[event.GetID()]++
+	m.sequence = append(m.sequence, event.GetID())
+	return nil
+}
+
+func newTestOutboxJob(t *testing.T, db *gorm.DB, mediatr ddd.Mediatr) *OutboxJob {
+	repository, err := outboxrepo.NewRepository(db)
+	assert.NoError(t, err)
+	registry, err := outbox.NewEventRegistry()
+	assert.NoError(t, err)
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.CreatedDomainEvent{}))
+	assert.NoError(t, err)
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.AssignedDomainEvent{}))
+	assert.NoError(t, err)
+	err = registry.RegisterDomainEvent(reflect.TypeOf(order.CompletedDomainEvent{}))
+	assert.NoError(t, err)
+
+	job, err := NewOutboxJob(repository, registry, mediatr)
+	assert.NoError(t, err)
+	return job
+}
+
+func addOutboxMessages(t *testing.T, db *gorm.DB, count int) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, count)
+	for i := 0; i < count; i++ {
+		orderAggregate, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 5)
+		assert.NoError(t, err)
+		message, err := outbox.EncodeDomainEvent(order.NewCreatedDomainEvent(orderAggregate))
+		assert.NoError(t, err)
+		err = db.Create(&message).Error
+		assert.NoError(t, err)
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+// addOrderLifecycle сохраняет события создания, назначения и завершения одного заказа одной вставкой,
+// как это делает Unit of Work, и возвращает их идентификаторы в порядке возникновения
+func addOrderLifecycle(t *testing.T, db *gorm.DB) []uuid.UUID {
+	orderAggregate, err := order.NewOrder(uuid.New(), kernel.MinLocation(), 5)
+	assert.NoError(t, err)
+	err = orderAggregate.AssignCourier(uuid.New())
+	assert.NoError(t, err)
+	err = orderAggregate.Complete()
+	assert.NoError(t, err)
+
+	messages, err := outbox.EncodeDomainEvents(orderAggregate.GetDomainEvents())
+	assert.NoError(t, err)
+	err = db.Create(&messages).Error
+	assert.NoError(t, err)
+
+	ids := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+func countNotPublished(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	err := db.Model(&outbox.Message{}).Where("processed_at_utc IS NULL").Count(&count).Error
+	assert.NoError(t, err)
+	return count
+}
+
+func Test_OutboxJob_TwoReplicasPublishEachMessageOnce(t *testing.T) {
+	// Инициализируем окружение
+	_, db, err := setupTest(t)
+	assert.NoError(t, err)
+	ids := addOutboxMessages(t, db, 3*outboxBatchSize)
+
+	mediatr := newRecordingMediatr()
+	mediatr.delay = 5 * time.Millisecond
+	relays := []*OutboxJob{newTestOutboxJob(t, db, mediatr), newTestOutboxJob(t, db, mediatr)}
+
+	// Обе реплики работают одновременно, пока все сообщения не будут отправлены
+	var wg sync.WaitGroup
+	for _, relay := range relays {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); {
+				relay.Run()
+				if countNotPublished(t, db) == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Каждое сообщение отправлено ровно один раз и отмечено обработанным с одной попыткой
+	assert.Len(t, mediatr.published, len(ids))
+	for _, id := range ids {
+		assert.Equal(t, 1, mediatr.published[id], id)
+	}
+
+	var messages []outbox.Message
+	err = db.Find(&messages).Error
+	assert.NoError(t, err)
+	for _, message := range messages {
+		assert.NotNil(t, message.ProcessedAtUtc)
+		assert.Equal(t, 1, message.Attempts)
+		assert.Nil(t, message.LastError)
+	}
+}
+
+func Test_OutboxJob_TracksFailedAttempts(t *testing.T) {
+	// Инициализируем окружение
+	_, db, err := setupTest(t)
+	assert.NoError(t, err)
+	ids := addOutboxMessages(t, db, 1)
+
+	mediatr := newRecordingMediatr()
+	mediatr.err = errors.New("kafka is unavailable")
+	relay := newTestOutboxJob(t, db, mediatr)
+
+	// Неудачная попытка сохраняется вместе с причиной
+	relay.Run()
+
+	var message outbox.Message
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.Nil(t, message.ProcessedAtUtc)
+	assert.Equal(t, 1, message.Attempts)
+	if assert.NotNil(t, message.LastError) {
+		assert.Equal(t, "kafka is unavailable", *message.LastError)
+	}
+
+	if assert.NotNil(t, message.NextAttemptAtUtc) {
+		assert.True(t, message.NextAttemptAtUtc.After(time.Now()))
+	}
+
+	// Пока пауза не прошла, сообщение не отправляется повторно
+	relay.Run()
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.Equal(t, 1, message.Attempts)
+
+	// Сколько бы попыток ни накопилось за простой брокера, сообщение не остается брошенным
+	err = db.Model(&outbox.Message{}).Where("id = ?", ids[0]).
+		Updates(map[string]any{"attempts": 100, "next_attempt_at_utc": time.Now().UTC().Add(-time.Second)}).Error
+	assert.NoError(t, err)
+
+	// После восстановления брокера сообщение отправляется, как только подошла его очередь
+	mediatr.err = nil
+	relay.Run()
+	assert.Equal(t, 1, mediatr.published[ids[0]])
+
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.NotNil(t, message.ProcessedAtUtc)
+	assert.Equal(t, 101, message.Attempts)
+	assert.Nil(t, message.LastError)
+	assert.Nil(t, message.NextAttemptAtUtc)
+}
+
+func Test_OutboxBackoff_GrowsUpToLimit(t *testing.T) {
+	assert.Equal(t, outboxMinBackoff, outboxBackoff(1))
+	assert.Equal(t, 2*outboxMinBackoff, outboxBackoff(2))
+	assert.Equal(t, 4*outboxMinBackoff, outboxBackoff(3))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(10))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(1000))
+}
+
+func Test_OutboxJob_KeepsAggregateEventsInOrder(t *testing.T) {
+	// Инициализируем окружение
+	_, db, err := setupTest(t)
+	assert.NoError(t, err)
+	ids := addOrderLifecycle(t, db)
+
+	mediatr := newRecordingMediatr()
+	mediatr.err = errors.New("kafka is unavailable")
+	mediatr.failName = "CreatedDomainEvent"
+	relay := newTestOutboxJob(t, db, mediatr)
+
+	// Пока не ушло создание заказа, назначение и завершение ждут и даже не пытаются отправиться
+	relay.Run()
+	assert.Empty(t, mediatr.sequence)
+
+	var messages []outbox.Message
+	err = db.Order("sequence_number ASC").Find(&messages).Error
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 3) {
+		assert.Equal(t, 1, messages[0].Attempts)
+		assert.Equal(t, 0, messages[1].Attempts)
+		assert.Equal(t, 0, messages[2].Attempts)
+	}
+
+	// После восстановления брокера события уходят в порядке возникновения
+	mediatr.err = nil
+	err = db.Model(&outbox.Message{}).Where("id = ?", ids[0]).
+		Update("next_attempt_at_utc", time.Now().UTC().Add(-time.Second)).Error
+	assert.NoError(t, err)
+
+	relay.Run()
+	assert.Equal(t, ids, mediatr.sequence)
+	assert.Equal(t, int64(0), countNotPublished(t, db))
+}
+
+func Test_OutboxJob_ParksUndecodableMessage(t *testing.T) {
+	// Инициализируем окружение
+	_, db, err := setupTest(t)
+	assert.NoError(t, err)
+	ids := addOrderLifecycle(t, db)
+
+	// Первое событие заказа испорчено и не декодируется
+	err = db.Model(&outbox.Message{}).Where("id = ?", ids[0]).Update("payload", []byte("{")).Error
+	assert.NoError(t, err)
+
+	mediatr := newRecordingMediatr()
+	relay := newTestOutboxJob(t, db, mediatr)
+	relay.Run()
+
+	// Испорченное сообщение отложено после первой же попытки вместе с причиной и не ждет повтора
+	var message outbox.Message
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.Nil(t, message.ProcessedAtUtc)
+	assert.NotNil(t, message.ParkedAtUtc)
+	assert.Nil(t, message.NextAttemptAtUtc)
+	assert.Equal(t, 1, message.Attempts)
+	if assert.NotNil(t, message.LastError) {
+		assert.Contains(t, *message.LastError, "failed to decode payload")
+	}
+
+	// Со следующего запуска отложенное сообщение не повторяется и не задерживает остальные события заказа
+	relay.Run()
+	assert.Equal(t, ids[1:], mediatr.sequence)
+
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.Equal(t, 1, message.Attempts)
+}
+
+func Test_OutboxJob_ParksMessageAfterMaxAttempts(t *testing.T) {
+	// Инициализируем окружение
+	_, db, err := setupTest(t)
+	assert.NoError(t, err)
+	ids := addOutboxMessages(t, db, 1)
+
+	// Остается последняя попытка
+	err = db.Model(&outbox.Message{}).Where("id = ?", ids[0]).
+		Update("attempts", outboxMaxAttempts-1).Error
+	assert.NoError(t, err)
+
+	mediatr := newRecordingMediatr()
+	mediatr.err = errors.New("kafka is unavailable")
+	relay := newTestOutboxJob(t, db, mediatr)
+	relay.Run()
+
+	// Исчерпавшее попытки сообщение отложено вместе с последней ошибкой
+	var message outbox.Message
+	err = db.First(&message, "id = ?", ids[0]).Error
+	assert.NoError(t, err)
+	assert.Nil(t, message.ProcessedAtUtc)
+	assert.NotNil(t, message.ParkedAtUtc)
+	assert.Nil(t, message.NextAttemptAtUtc)
+	assert.Equal(t, outboxMaxAttempts, message.Attempts)
+	if assert.NotNil(t, message.LastError) {
+		assert.Equal(t, "kafka is unavailable", *message.LastError)
+	}
+}
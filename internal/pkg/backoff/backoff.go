@@ -0,0 +1,13 @@
+package backoff
+
+import "time"
+
+// Exponential - пауза после неудачной попытки с номером attempt, попытки считаются с 1.
+// Первая пауза равна initial, каждая следующая вдвое больше предыдущей, но не больше max
+func Exponential(attempt int, initial, max time.Duration) time.Duration {
+	backoff := initial
+	for i := 1; i < attempt && backoff < max; i++ {
+		backoff *= 2
+	}
+	return min(backoff, max)
+}
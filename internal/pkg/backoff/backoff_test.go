@@ -0,0 +1,22 @@
+package backoff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Exponential(t *testing.T) {
+	tests := map[int]time.Duration{
+		0:    time.Second,
+		1:    time.Second,
+		2:    2 * time.Second,
+		3:    4 * time.Second,
+		6:    32 * time.Second,
+		7:    time.Minute,
+		1000: time.Minute,
+	}
+	for attempt, expected := range tests {
+		assert.Equal(t, expected, Exponential(attempt, time.Second, time.Minute), attempt)
+	}
+}
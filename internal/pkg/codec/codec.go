@@ -0,0 +1,68 @@
+package codec
+
+import (
+	"delivery/internal/pkg/errs"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// Header - заголовок сообщения Kafka, в котором передается формат тела
+const Header = "content-type"
+
+const (
+	JSON     = "application/json"
+	Protobuf = "application/x-protobuf"
+)
+
+// Parse приводит значение заголовка к одному из поддерживаемых форматов. Сообщения без заголовка
+// считаются JSON - так их отправляли до появления согласования формата
+func Parse(value string) (string, error) {
+	mediaType, _, _ := strings.Cut(value, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	switch mediaType {
+	case "", JSON:
+		return JSON, nil
+	case Protobuf, "application/protobuf", "application/vnd.google.protobuf":
+		return Protobuf, nil
+	default:
+		return "", errs.NewValueIsInvalidErrorWithCause("contentType",
+			fmt.Errorf("unsupported content type %q", value))
+	}
+}
+
+func Marshal(contentType string, message proto.Message) ([]byte, error) {
+	contentType, err := Parse(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == Protobuf {
+		return proto.Marshal(message)
+	}
+	// Номера вместо имен enum - такой JSON получали потребители, пока он собирался через encoding/json
+	return protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(message)
+}
+
+func Unmarshal(contentType string, data []byte, message proto.Message) error {
+	contentType, err := Parse(contentType)
+	if err != nil {
+		return err
+	}
+	if contentType == Protobuf {
+		return proto.Unmarshal(data, message)
+	}
+
+	err = protojson.Unmarshal(data, message)
+	if err == nil {
+		return nil
+	}
+	// protojson знает только точные имена полей. Старые производители собирали JSON через encoding/json:
+	// с именами в другом регистре и лишними полями. Такое сообщение разбираем так же, как раньше
+	proto.Reset(message)
+	if legacyErr := json.Unmarshal(data, message); legacyErr != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package codec
+
+import (
+	"delivery/internal/generated/queues/basketconfirmedpb"
+	"delivery/internal/generated/queues/orderstatuschangedpb"
+	"delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := map[string]string{
+		"":                                 JSON,
+		"application/json":                 JSON,
+		"Application/JSON; charset=utf-8":  JSON,
+		"application/x-protobuf":           Protobuf,
+		"application/protobuf":             Protobuf,
+		" application/vnd.google.protobuf": Protobuf,
+	}
+	for value, expected := range tests {
+		contentType, err := Parse(value)
+
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, contentType, value)
+	}
+}
+
+func Test_Parse_RejectsUnsupportedContentType(t *testing.T) {
+	_, err := Parse("text/xml")
+
+	assert.ErrorIs(t, err, errs.ErrValueIsInvalid)
+}
+
+func Test_MarshalUnmarshal_RoundTrip(t *testing.T) {
+	event := &basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Address:  &basketconfirmedpb.Address{Street: "Тверская"},
+		Items:    []*basketconfirmedpb.Item{{Title: "Кофе", Price: 9.5, Quantity: 2}},
+		Volume:   5,
+	}
+	for _, contentType := range []string{JSON, Protobuf} {
+		data, err := Marshal(contentType, event)
+		assert.NoError(t, err)
+
+		var decoded basketconfirmedpb.BasketConfirmedIntegrationEvent
+		err = Unmarshal(contentType, data, &decoded)
+
+		assert.NoError(t, err, contentType)
+		assert.True(t, proto.Equal(event, &decoded), contentType)
+	}
+}
+
+func Test_Unmarshal_AcceptsLegacyJSON(t *testing.T) {
+	// Так выглядело сообщение, собранное encoding/json, и в нем лишнее поле
+	data := []byte(`{"basketId":"0f8fad5b-d9cb-469f-a165-70867728950e","address":{"street":"Тверская"},"Volume":5,"extra":true}`)
+
+	var event basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err := Unmarshal("", data, &event)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", event.GetBasketId())
+	assert.Equal(t, "Тверская", event.GetAddress().GetStreet())
+	assert.Equal(t, int32(5), event.GetVolume())
+}
+
+func Test_Unmarshal_AcceptsLegacyFieldCasing(t *testing.T) {
+	// encoding/json сопоставлял поля без учета регистра, и производители так и отправляли
+	data := []byte(`{"BasketId":"0f8fad5b-d9cb-469f-a165-70867728950e","Address":{"Street":"Тверская","house":"1"},` +
+		`"Items":[{"Id":"1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed","GoodId":"6ec0bd7f-11c0-43da-975e-2a8ad9ebae0b",` +
+		`"Title":"Кофе","Price":9.5,"Quantity":2}],"DeliveryPeriod":{"From":10,"To":12},"volume":5}`)
+
+	var event basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err := Unmarshal(JSON, data, &event)
+
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&basketconfirmedpb.BasketConfirmedIntegrationEvent{
+		BasketId: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Address:  &basketconfirmedpb.Address{Street: "Тверская", House: "1"},
+		Items: []*basketconfirmedpb.Item{{
+			Id:       "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed",
+			GoodId:   "6ec0bd7f-11c0-43da-975e-2a8ad9ebae0b",
+			Title:    "Кофе",
+			Price:    9.5,
+			Quantity: 2,
+		}},
+		DeliveryPeriod: &basketconfirmedpb.DeliveryPeriod{From: 10, To: 12},
+		Volume:         5,
+	}, &event), event.String())
+}
+
+func Test_Unmarshal_RejectsMalformedJSON(t *testing.T) {
+	var event basketconfirmedpb.BasketConfirmedIntegrationEvent
+	err := Unmarshal(JSON, []byte(`{"basketId":`), &event)
+
+	assert.Error(t, err)
+}
+
+func Test_Marshal_WritesEnumsAsNumbersInJSON(t *testing.T) {
+	event := &orderstatuschangedpb.OrderStatusChangedIntegrationEvent{
+		OrderId:     "0f8fad5b-d9cb-469f-a165-70867728950e",
+		OrderStatus: orderstatuschangedpb.OrderStatus(1),
+	}
+
+	data, err := Marshal(JSON, event)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"orderId":"0f8fad5b-d9cb-469f-a165-70867728950e","orderStatus":1}`, string(data))
+}
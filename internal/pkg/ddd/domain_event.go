@@ -8,3 +8,10 @@ type DomainEvent interface {
 	GetID() uuid.UUID
 	GetName() string
 }
+
+// AggregateDomainEvent - доменное событие, привязанное к своему агрегату.
+// События одного агрегата Outbox отправляет строго в порядке возникновения
+type AggregateDomainEvent interface {
+	DomainEvent
+	GetAggregateID() uuid.UUID
+}
@@ -0,0 +1,23 @@
+package inbox
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var ErrAlreadyProcessed = errors.New("inbox message has already been processed")
+
+// Message - входящее сообщение из Kafka, которое уже обработано. Пишется в одной транзакции
+// с результатом обработки, поэтому повторная доставка того же сообщения или той же корзины пропускается
+type Message struct {
+	Topic          string    `gorm:"primaryKey"`
+	Partition      int32     `gorm:"primaryKey;autoIncrement:false"`
+	Offset         int64     `gorm:"primaryKey;autoIncrement:false"`
+	BasketID       uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	ProcessedAtUtc time.Time
+}
+
+func (Message) TableName() string {
+	return "inbox"
+}
@@ -41,13 +41,18 @@ func EncodeDomainEvent(domainEvent ddd.DomainEvent) (Message, error) {
 		return Message{}, fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	return Message{
+	message := Message{
 		ID:             domainEvent.GetID(),
 		Name:           domainEvent.GetName(),
 		Payload:        payload,
 		OccurredAtUtc:  time.Now().UTC(),
 		ProcessedAtUtc: nil,
-	}, nil
+	}
+	if aggregateEvent, ok := domainEvent.(ddd.AggregateDomainEvent); ok {
+		aggregateID := aggregateEvent.GetAggregateID()
+		message.AggregateID = &aggregateID
+	}
+	return message, nil
 }
 
 func EncodeDomainEvents(domainEvent []ddd.DomainEvent) ([]Message, error) {
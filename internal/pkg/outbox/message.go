@@ -11,6 +11,24 @@ type Message struct {
 	Payload        []byte
 	OccurredAtUtc  time.Time
 	ProcessedAtUtc *time.Time
+
+	// Порядковый номер записи, задает очередь отправки даже для событий, возникших в один момент
+	SequenceNumber int64 `gorm:"autoIncrement;not null;uniqueIndex"`
+
+	// Агрегат, породивший событие: следующее событие агрегата не отправляется, пока не ушло предыдущее.
+	// Пусто - событие отправляется без оглядки на остальные
+	AggregateID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Сколько раз сообщение пытались отправить и чем закончилась последняя неудачная попытка
+	Attempts  int `gorm:"not null;default:0"`
+	LastError *string
+
+	// Раньше этого момента неудачное сообщение повторно не отправляется, пусто - отправлять сразу
+	NextAttemptAtUtc *time.Time `gorm:"index"`
+
+	// Когда сообщение отложено для ручного разбора: оно не декодируется или исчерпало попытки.
+	// Отложенное сообщение больше не отправляется и не задерживает следующие события своего агрегата
+	ParkedAtUtc *time.Time `gorm:"index"`
 }
 
 func (Message) TableName() string {
@@ -28,6 +28,7 @@ const (
 	OrderStatus_Created   OrderStatus = 1
 	OrderStatus_Assigned  OrderStatus = 2
 	OrderStatus_Completed OrderStatus = 3
+	OrderStatus_Cancelled OrderStatus = 4
 )
 
 // Enum value maps for OrderStatus.
@@ -37,12 +38,14 @@ var (
 		1: "Created",
 		2: "Assigned",
 		3: "Completed",
+		4: "Cancelled",
 	}
 	OrderStatus_value = map[string]int32{
 		"None":      0,
 		"Created":   1,
 		"Assigned":  2,
 		"Completed": 3,
+		"Cancelled": 4,
 	}
 )
 
@@ -132,12 +135,13 @@ const file_api_proto_order_status_changed_proto_rawDesc = "" +
 	"$api/proto/order_status_changed.proto\x12\x12OrderStatusChanged\"\x81\x01\n" +
 	"\"OrderStatusChangedIntegrationEvent\x12\x18\n" +
 	"\aorderId\x18\x01 \x01(\tR\aorderId\x12A\n" +
-	"\vorderStatus\x18\x02 \x01(\x0e2\x1f.OrderStatusChanged.OrderStatusR\vorderStatus*A\n" +
+	"\vorderStatus\x18\x02 \x01(\x0e2\x1f.OrderStatusChanged.OrderStatusR\vorderStatus*P\n" +
 	"\vOrderStatus\x12\b\n" +
 	"\x04None\x10\x00\x12\v\n" +
 	"\aCreated\x10\x01\x12\f\n" +
 	"\bAssigned\x10\x02\x12\r\n" +
-	"\tCompleted\x10\x03B\x1dZ\x1bqueues/orderstatuschangedpbb\x06proto3"
+	"\tCompleted\x10\x03\x12\r\n" +
+	"\tCancelled\x10\x04B\x1dZ\x1bqueues/orderstatuschangedpbb\x06proto3"
 
 var (
 	file_api_proto_order_status_changed_proto_rawDescOnce sync.Once